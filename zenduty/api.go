@@ -0,0 +1,57 @@
+package zenduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// defaultBaseURL is the endpoint used when base_url is not set, the same one
+// the SDK defaults to.
+const defaultBaseURL = "https://www.zenduty.com"
+
+// request sends a request to an endpoint the SDK does not cover and decodes
+// the JSON response into out, unless out is nil. It goes through the same
+// transport as the SDK client, so it is retried, rate limited and logged the
+// same way, and error responses come back as an *APIError.
+func (m *Meta) request(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	baseURL := m.baseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(baseURL, "/")+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Token "+m.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := m.transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response of %s %s: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package zenduty
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// maxErrorBodyLength bounds how much of a body that is not JSON, such as an
+// HTML error page from a proxy, ends up in an error message.
+const maxErrorBodyLength = 512
+
+// APIError is returned for every response of the Zenduty API with a status
+// of 400 or above. Callers branch on StatusCode instead of matching error text.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Method     string
+	Path       string
+
+	// Code is the error code sent by the API, if any.
+	Code string
+	// Message is the error message that is not tied to a single field.
+	Message string
+	// FieldErrors holds the validation messages the API reported for
+	// individual fields of the request body.
+	FieldErrors []FieldError
+}
+
+// FieldError is a validation message for one field of a request body. Path
+// points at the field, for example rules[2].targets[0].target_id.
+type FieldError struct {
+	Path    cty.Path
+	Message string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s: %s", e.Method, e.Path, e.Status)
+	if e.Code != "" {
+		msg += fmt.Sprintf(" (%s)", e.Code)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	for _, fieldErr := range e.FieldErrors {
+		msg += fmt.Sprintf("; %s: %s", formatPath(fieldErr.Path), fieldErr.Message)
+	}
+	return msg
+}
+
+// NotFound reports whether the requested object does not exist.
+func (e *APIError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// isNotFoundError reports whether err is a 404 from the API. apiErrorTransport
+// fails every request the API rejects with an APIError, and the SDK returns
+// the *url.Error of the HTTP client around it, so errors.As finds it.
+func isNotFoundError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.NotFound()
+}
+
+// apiErrorDiags turns an error returned by an API call into diagnostics. An
+// APIError yields one diagnostic per field the API rejected, attached to the
+// matching attribute since the request bodies use the attribute names, and
+// one diagnostic for any message that is not tied to a field.
+func apiErrorDiags(err error) diag.Diagnostics {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return diag.FromErr(err)
+	}
+
+	summary := fmt.Sprintf("Zenduty API error: %s", apiErr.Status)
+	var diags diag.Diagnostics
+	if apiErr.Message != "" || len(apiErr.FieldErrors) == 0 {
+		detail := apiErr.Message
+		if detail == "" {
+			detail = apiErr.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   fmt.Sprintf("%s %s\n%s", apiErr.Method, apiErr.Path, detail),
+		})
+	}
+	for _, fieldErr := range apiErr.FieldErrors {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid value for %s", formatPath(fieldErr.Path)),
+			Detail:        fieldErr.Message,
+			AttributePath: fieldErr.Path,
+		})
+	}
+	return diags
+}
+
+// apiErrorTransport turns error responses into an *APIError. It sits above
+// the retry transport, so it only sees the final response of a request.
+type apiErrorTransport struct {
+	base http.RoundTripper
+}
+
+func (t *apiErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode < 400 {
+		return resp, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	return nil, parseAPIError(req, resp, body)
+}
+
+func parseAPIError(req *http.Request, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Method:     req.Method,
+		Path:       req.URL.Path,
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		message := strings.TrimSpace(string(body))
+		if len(message) > maxErrorBodyLength {
+			message = message[:maxErrorBodyLength] + "..."
+		}
+		apiErr.Message = message
+		return apiErr
+	}
+
+	var messages []string
+	switch v := data.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value := v[key]
+			switch key {
+			case "code", "error_code":
+				apiErr.Code = fmt.Sprint(value)
+			case "detail", "message", "error", "non_field_errors":
+				messages = append(messages, collectMessages(value)...)
+			default:
+				apiErr.FieldErrors = append(apiErr.FieldErrors, collectFieldErrors(cty.GetAttrPath(key), value)...)
+			}
+		}
+	default:
+		messages = collectMessages(v)
+	}
+	apiErr.Message = strings.Join(messages, " ")
+
+	return apiErr
+}
+
+func collectMessages(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case []interface{}:
+		var messages []string
+		for _, item := range v {
+			messages = append(messages, collectMessages(item)...)
+		}
+		return messages
+	}
+	encoded, _ := json.Marshal(value)
+	return []string{string(encoded)}
+}
+
+// collectFieldErrors walks the nested validation errors the API returns for
+// lists and objects, e.g. {"rules": [{}, {"delay": ["..."]}]}, and records
+// the path of every message it finds.
+func collectFieldErrors(path cty.Path, value interface{}) []FieldError {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var fieldErrs []FieldError
+		for _, key := range keys {
+			fieldErrs = append(fieldErrs, collectFieldErrors(path.GetAttr(key), v[key])...)
+		}
+		return fieldErrs
+	case []interface{}:
+		var fieldErrs []FieldError
+		for i, item := range v {
+			switch item.(type) {
+			case map[string]interface{}, []interface{}:
+				fieldErrs = append(fieldErrs, collectFieldErrors(path.IndexInt(i), item)...)
+			default:
+				fieldErrs = append(fieldErrs, collectFieldErrors(path, item)...)
+			}
+		}
+		return fieldErrs
+	case nil:
+		return nil
+	}
+	return []FieldError{{Path: path, Message: strings.Join(collectMessages(value), " ")}}
+}
+
+// formatPath renders a path the way it is written in configuration, for
+// example rules[2].targets[0].target_id.
+func formatPath(path cty.Path) string {
+	var b strings.Builder
+	for _, step := range path {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(s.Name)
+		case cty.IndexStep:
+			if s.Key.Type() == cty.Number {
+				i, _ := s.Key.AsBigFloat().Int64()
+				fmt.Fprintf(&b, "[%d]", i)
+			} else if s.Key.Type() == cty.String {
+				fmt.Fprintf(&b, "[%q]", s.Key.AsString())
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,171 @@
+package zenduty
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestParseAPIError(t *testing.T) {
+	cases := []struct {
+		name        string
+		body        string
+		wantCode    string
+		wantMessage string
+		// wantFields are the field errors as "path: message".
+		wantFields []string
+	}{
+		{
+			name:        "detail",
+			body:        `{"detail": "Not found."}`,
+			wantMessage: "Not found.",
+		},
+		{
+			name:        "non_field_errors",
+			body:        `{"non_field_errors": ["Name is taken.", "Try another one."]}`,
+			wantMessage: "Name is taken. Try another one.",
+		},
+		{
+			name:        "code and message",
+			body:        `{"error_code": 1001, "message": "Invalid team."}`,
+			wantCode:    "1001",
+			wantMessage: "Invalid team.",
+		},
+		{
+			name:       "fields",
+			body:       `{"code": "invalid", "name": ["This field is required."], "summary": "Too long."}`,
+			wantCode:   "invalid",
+			wantFields: []string{"name: This field is required.", "summary: Too long."},
+		},
+		{
+			name:       "several messages for a field",
+			body:       `{"name": ["This field is required.", "Must be unique."]}`,
+			wantFields: []string{"name: This field is required.", "name: Must be unique."},
+		},
+		{
+			name:       "nested object",
+			body:       `{"user": {"email": ["Enter a valid email address."]}}`,
+			wantFields: []string{"user.email: Enter a valid email address."},
+		},
+		{
+			name: "nested lists",
+			body: `{"rules": [{}, {"delay": ["Must not decrease."], "targets": [{}, {"target_id": ["Invalid user."]}]}]}`,
+			wantFields: []string{
+				"rules[1].delay: Must not decrease.",
+				"rules[1].targets[1].target_id: Invalid user.",
+			},
+		},
+		{
+			name:        "fields and non_field_errors",
+			body:        `{"non_field_errors": ["Invalid schedule."], "layers": [{"shift_length": ["Must be positive."]}]}`,
+			wantMessage: "Invalid schedule.",
+			wantFields:  []string{"layers[0].shift_length: Must be positive."},
+		},
+		{
+			name:       "null field",
+			body:       `{"name": null, "summary": ["Too long."]}`,
+			wantFields: []string{"summary: Too long."},
+		},
+		{
+			name:        "list",
+			body:        `["Something went wrong.", "Try again."]`,
+			wantMessage: "Something went wrong. Try again.",
+		},
+		{
+			name:        "not JSON",
+			body:        "  <html>Bad gateway</html>\n",
+			wantMessage: "<html>Bad gateway</html>",
+		},
+		{
+			name:        "long body that is not JSON",
+			body:        strings.Repeat("x", maxErrorBodyLength+1),
+			wantMessage: strings.Repeat("x", maxErrorBodyLength) + "...",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "https://www.zenduty.com/api/account/teams/", nil)
+			resp := &http.Response{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"}
+			apiErr := parseAPIError(req, resp, []byte(c.body))
+
+			if apiErr.StatusCode != http.StatusBadRequest || apiErr.Method != http.MethodPost || apiErr.Path != "/api/account/teams/" {
+				t.Errorf("request = %d %s %s, want 400 POST /api/account/teams/", apiErr.StatusCode, apiErr.Method, apiErr.Path)
+			}
+			if apiErr.Code != c.wantCode {
+				t.Errorf("Code = %q, want %q", apiErr.Code, c.wantCode)
+			}
+			if apiErr.Message != c.wantMessage {
+				t.Errorf("Message = %q, want %q", apiErr.Message, c.wantMessage)
+			}
+			var fields []string
+			for _, fieldErr := range apiErr.FieldErrors {
+				fields = append(fields, formatPath(fieldErr.Path)+": "+fieldErr.Message)
+			}
+			if !reflect.DeepEqual(fields, c.wantFields) {
+				t.Errorf("FieldErrors = %q, want %q", fields, c.wantFields)
+			}
+		})
+	}
+}
+
+func TestCollectFieldErrors(t *testing.T) {
+	layers := cty.GetAttrPath("layers")
+	cases := []struct {
+		name  string
+		value interface{}
+		want  []FieldError
+	}{
+		{"null", nil, nil},
+		{"message", "Required.", []FieldError{{layers, "Required."}}},
+		{"messages", []interface{}{"Required.", "Invalid."}, []FieldError{{layers, "Required."}, {layers, "Invalid."}}},
+		{
+			"objects in a list",
+			[]interface{}{
+				map[string]interface{}{},
+				map[string]interface{}{"users": []interface{}{map[string]interface{}{"user": []interface{}{"Unknown user."}}}},
+			},
+			[]FieldError{{layers.IndexInt(1).GetAttr("users").IndexInt(0).GetAttr("user"), "Unknown user."}},
+		},
+		{
+			"keys in order",
+			map[string]interface{}{"shift_length": "Must be positive.", "name": "Required."},
+			[]FieldError{{layers.GetAttr("name"), "Required."}, {layers.GetAttr("shift_length"), "Must be positive."}},
+		},
+		{"number", 42.0, []FieldError{{layers, "42"}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := collectFieldErrors(layers, c.value)
+			if len(got) != len(c.want) {
+				t.Fatalf("collectFieldErrors() = %v, want %v", got, c.want)
+			}
+			for i := range got {
+				if !got[i].Path.Equals(c.want[i].Path) || got[i].Message != c.want[i].Message {
+					t.Errorf("field error %d = %s: %q, want %s: %q", i, formatPath(got[i].Path), got[i].Message, formatPath(c.want[i].Path), c.want[i].Message)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatPath(t *testing.T) {
+	cases := []struct {
+		path cty.Path
+		want string
+	}{
+		{nil, ""},
+		{cty.GetAttrPath("name"), "name"},
+		{cty.GetAttrPath("rules").IndexInt(2).GetAttr("targets").IndexInt(0).GetAttr("target_id"), "rules[2].targets[0].target_id"},
+		{cty.GetAttrPath("labels").Index(cty.StringVal("env")), `labels["env"]`},
+		{cty.GetAttrPath("layers").IndexInt(0).IndexInt(1), "layers[0][1]"},
+	}
+	for _, c := range cases {
+		if got := formatPath(c.path); got != c.want {
+			t.Errorf("formatPath(%#v) = %q, want %q", c.path, got, c.want)
+		}
+	}
+}
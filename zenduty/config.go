@@ -1,39 +1,122 @@
 package zenduty
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
 )
 
 type Config struct {
-	Token   string
-	BaseURL string
+	Token        string
+	BaseURL      string
+	MaxRetries   int
+	RetryMaxWait time.Duration
+
+	// RequestsPerSecond caps the rate of requests sent by all operations
+	// of the provider instance. Zero disables the limit.
+	RequestsPerSecond float64
+
+	RequestTimeout     time.Duration
+	ProxyURL           string
+	CACertFile         string
+	CACertPEM          string
+	InsecureSkipVerify bool
 }
 
 const invalidCreds = "no valid credentials found for zenduty provider"
 
-func (c *Config) Client() (*client.Client, error) {
+// Meta builds the meta value described by the config. It is called once per
+// provider instance from providerConfigure; CRUD functions reach the API
+// through Meta instead of building their own client.
+func (c *Config) Meta() (*Meta, error) {
 	if c.Token == "" {
 		return nil, fmt.Errorf(invalidCreds)
 	}
 
-	httpClient := http.DefaultClient
+	httpTransport, err := c.newHTTPTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	var transport http.RoundTripper = httpTransport
+	if c.RequestTimeout > 0 {
+		transport = &timeoutTransport{
+			base:    transport,
+			timeout: c.RequestTimeout,
+		}
+	}
+	transport = &loggingTransport{base: transport}
+	if c.RequestsPerSecond > 0 {
+		transport = &rateLimitTransport{
+			base:    transport,
+			limiter: newRateLimiter(c.RequestsPerSecond),
+		}
+	}
+	transport = &apiErrorTransport{
+		base: &retryTransport{
+			base:       transport,
+			maxRetries: c.MaxRetries,
+			maxWait:    c.RetryMaxWait,
+		},
+	}
 
-	config := &client.Config{
-		BaseURL:    c.BaseURL,
-		HTTPClient: httpClient,
-		Token:      c.Token,
+	meta := &Meta{
+		token:     c.Token,
+		baseURL:   c.BaseURL,
+		transport: transport,
 	}
 
-	client, err := client.NewClient(config)
-	if err != nil {
+	// Build a client once so that configuration errors are reported by
+	// providerConfigure rather than by the first resource operation.
+	if _, err := meta.Client(context.Background()); err != nil {
 		return nil, err
 	}
 
 	log.Printf("[INFO] zenduty client configured")
 
-	return client, nil
+	return meta, nil
+}
+
+// Meta is the value providerConfigure hands to every resource and data source
+// as their meta argument. It holds the HTTP transport shared by all operations
+// of a provider instance, and with it the rate limiter.
+type Meta struct {
+	token     string
+	baseURL   string
+	transport http.RoundTripper
+}
+
+// Client returns an API client whose requests carry ctx, so the deadline of
+// the resource timeout and cancellation by Terraform reach the HTTP layer.
+// The SDK builds its requests without a context, so the client is bound to
+// ctx; it shares the transport, and with it the connection pool, retries and
+// rate limiter, with every other operation of the provider instance.
+func (m *Meta) Client(ctx context.Context) (*client.Client, error) {
+	apiclient, err := client.NewClient(&client.Config{
+		BaseURL: m.baseURL,
+		HTTPClient: &http.Client{
+			Transport: &contextTransport{base: m.transport, ctx: ctx},
+		},
+		Token: m.token,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building zenduty client: %w", err)
+	}
+	return apiclient, nil
+}
+
+// contextTransport sends every request with ctx. The SDK builds its requests
+// without a context, so this is how the deadline and cancellation of a CRUD
+// function reach the retry, rate limit and HTTP transports below it.
+type contextTransport struct {
+	base http.RoundTripper
+	ctx  context.Context
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(t.ctx))
 }
@@ -2,7 +2,6 @@ package zenduty
 
 import (
 	"context"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -112,7 +111,10 @@ func dataSourceAlertRules() *schema.Resource {
 }
 
 func dataSourceAlertRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	serviceID := d.Get("service_id").(string)
@@ -123,7 +125,7 @@ func dataSourceAlertRulesRead(ctx context.Context, d *schema.ResourceData, m int
 	if alertRuleID != "" {
 		rule, err := apiclient.AlertRules.GetAlertRule(teamID, serviceID, integrationID, alertRuleID)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 		items := make([]map[string]interface{}, 1)
 
@@ -156,14 +158,14 @@ func dataSourceAlertRulesRead(ctx context.Context, d *schema.ResourceData, m int
 		if err := d.Set("alertrules", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(teamID, serviceID, integrationID, alertRuleID))
 
 		return diags
 	} else {
 
 		rules, err := apiclient.AlertRules.GetAlertRules(teamID, serviceID, integrationID)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 		items := make([]map[string]interface{}, len(rules))
 		for i, rule := range rules {
@@ -196,7 +198,7 @@ func dataSourceAlertRulesRead(ctx context.Context, d *schema.ResourceData, m int
 		if err := d.Set("alertrules", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(teamID, serviceID, integrationID, alertRuleID))
 
 		return diags
 	}
@@ -37,7 +37,10 @@ func dataSourceUserContacts() *schema.Resource {
 
 func dataSourceNotificationRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	username := d.Get("user_id").(string)
 	if emptyString(username) {
 		return diag.Errorf("username is required")
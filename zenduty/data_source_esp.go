@@ -2,7 +2,6 @@ package zenduty
 
 import (
 	"context"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -106,7 +105,10 @@ func dataSourceEsp() *schema.Resource {
 }
 
 func dataSourceEspsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	espID := d.Get("esp_id").(string)
@@ -115,7 +117,7 @@ func dataSourceEspsRead(ctx context.Context, d *schema.ResourceData, m interface
 	if espID != "" {
 		esp, err := apiclient.Esp.GetEscalationPolicyByID(teamID, espID)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 		items := make([]map[string]interface{}, 1)
 		item := make(map[string]interface{})
@@ -151,14 +153,14 @@ func dataSourceEspsRead(ctx context.Context, d *schema.ResourceData, m interface
 		if err := d.Set("escalation_policies", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(teamID, espID))
 
 		return diags
 	} else {
 
 		esps, err := apiclient.Esp.GetEscalationPolicy(teamID)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 		items := make([]map[string]interface{}, len(esps))
 		for i, esp := range esps {
@@ -194,7 +196,7 @@ func dataSourceEspsRead(ctx context.Context, d *schema.ResourceData, m interface
 		if err := d.Set("escalation_policies", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(teamID, espID))
 
 		return diags
 	}
@@ -2,7 +2,6 @@ package zenduty
 
 import (
 	"context"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -70,7 +69,10 @@ func dataSourceGlobalRoutingRules() *schema.Resource {
 }
 
 func dataSourceGlobalRoutingRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	routerID := d.Get("router_id").(string)
 	ruleID := d.Get("rule_id").(string)
@@ -80,7 +82,7 @@ func dataSourceGlobalRoutingRulesRead(ctx context.Context, d *schema.ResourceDat
 		var diags diag.Diagnostics
 		rule, err := apiclient.GlobalRouter.GetGlobalRoutingRule(routerID, ruleID)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 
 		items := make([]map[string]interface{}, 1)
@@ -103,7 +105,7 @@ func dataSourceGlobalRoutingRulesRead(ctx context.Context, d *schema.ResourceDat
 		if err := d.Set("routing_rules", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(routerID, ruleID))
 
 		return diags
 	} else {
@@ -112,7 +114,7 @@ func dataSourceGlobalRoutingRulesRead(ctx context.Context, d *schema.ResourceDat
 
 		rules, err := apiclient.GlobalRouter.GetGlobalRoutingRules(routerID)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 
 		items := make([]map[string]interface{}, len(rules))
@@ -139,7 +141,7 @@ func dataSourceGlobalRoutingRulesRead(ctx context.Context, d *schema.ResourceDat
 			return diag.FromErr(err)
 		}
 
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(routerID, ruleID))
 		return diags
 	}
 }
@@ -2,10 +2,19 @@ package zenduty
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Zenduty/zenduty-go-sdk/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceIncidents() *schema.Resource {
@@ -13,12 +22,57 @@ func dataSourceIncidents() *schema.Resource {
 		ReadContext: dataSourceIncidentRead,
 		Schema: map[string]*schema.Schema{
 			"number": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(incidentNumberPattern, "must be an incident number"),
 			},
 			"status": {
-				Type:     schema.TypeString,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(incidentTriggered, incidentResolved),
+			},
+			"team_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: ValidateUUID(),
+			},
+			"service_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: ValidateUUID(),
+			},
+			"urgency": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(incidentUrgencyNames, false),
+			},
+			"priority_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: ValidateUUID(),
+			},
+			"tags": {
+				Type:     schema.TypeList,
 				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: ValidateUUID(),
+				},
+			},
+			"created_after": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"created_before": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
 			},
 			"results": &schema.Schema{
 				Type:     schema.TypeList,
@@ -169,10 +223,13 @@ func dataSourceIncidents() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						// "tags": {
-						// 	Type:     schema.TypeList,
-						// 	Computed: true,
-						// },
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
 						"sla": {
 							Type:     schema.TypeString,
 							Computed: true,
@@ -196,53 +253,261 @@ func dataSourceIncidents() *schema.Resource {
 	}
 }
 
+// incidentUrgencyNames are the values of the urgency filter, in the order of
+// the urgency numbers of the API starting at 0.
+var incidentUrgencyNames = []string{"low", "high"}
+
+// incidentPage is one page of the incident list. The SDK only returns the
+// first page, so the pages are requested with Meta.request, following Next.
+type incidentPage struct {
+	Next    *string           `json:"next"`
+	Results []json.RawMessage `json:"results"`
+}
+
+// incidentTags holds the tags of an incident, which client.Incident leaves
+// out.
+type incidentTags struct {
+	Tags []struct {
+		TeamTag string `json:"team_tag"`
+	} `json:"tags"`
+}
+
+// incidentFilter selects the incidents matching the arguments of the data
+// source. Zero values match any incident.
+type incidentFilter struct {
+	status        int
+	teamID        string
+	serviceID     string
+	urgency       int
+	priorityID    string
+	tags          []string
+	createdAfter  time.Time
+	createdBefore time.Time
+}
+
+func newIncidentFilter(d *schema.ResourceData) incidentFilter {
+	f := incidentFilter{
+		status:     d.Get("status").(int),
+		teamID:     d.Get("team_id").(string),
+		serviceID:  d.Get("service_id").(string),
+		urgency:    -1,
+		priorityID: d.Get("priority_id").(string),
+	}
+	if v, ok := d.GetOk("urgency"); ok {
+		f.urgency = indexOf(v.(string), incidentUrgencyNames)
+	}
+	for _, tag := range d.Get("tags").([]interface{}) {
+		f.tags = append(f.tags, tag.(string))
+	}
+	// Both were checked by validation.IsRFC3339Time.
+	if v, ok := d.GetOk("created_after"); ok {
+		f.createdAfter, _ = time.Parse(time.RFC3339, v.(string))
+	}
+	if v, ok := d.GetOk("created_before"); ok {
+		f.createdBefore, _ = time.Parse(time.RFC3339, v.(string))
+	}
+	return f
+}
+
+// query returns the path of the first page of the incident list, with the
+// filters the API applies itself as query parameters. Incidents are still
+// matched against the whole filter as they are read.
+func (f incidentFilter) query() string {
+	query := url.Values{"page": {"1"}}
+	if f.status != 0 {
+		query.Set("status", strconv.Itoa(f.status))
+	}
+	if f.teamID != "" {
+		query.Set("team_id", f.teamID)
+	}
+	if f.serviceID != "" {
+		query.Set("service_ids", f.serviceID)
+	}
+	if f.urgency != -1 {
+		query.Set("urgency", strconv.Itoa(f.urgency))
+	}
+	if f.priorityID != "" {
+		query.Set("priority_ids", f.priorityID)
+	}
+	if len(f.tags) > 0 {
+		query.Set("tag_ids", strings.Join(f.tags, ","))
+	}
+	if !f.createdAfter.IsZero() {
+		query.Set("from_date", f.createdAfter.UTC().Format(time.RFC3339))
+	}
+	if !f.createdBefore.IsZero() {
+		query.Set("to_date", f.createdBefore.UTC().Format(time.RFC3339))
+	}
+	return "/api/incidents/?" + query.Encode()
+}
+
+// nextIncidentPage returns the path of the page after incidents, or "" if it
+// is the last one. Only the path and query of Next are kept, so that the
+// pages are requested from the configured base URL.
+func nextIncidentPage(incidents incidentPage) (string, error) {
+	if incidents.Next == nil || *incidents.Next == "" || len(incidents.Results) == 0 {
+		return "", nil
+	}
+	next, err := url.Parse(*incidents.Next)
+	if err != nil {
+		return "", fmt.Errorf("invalid next page %q of the incident list: %w", *incidents.Next, err)
+	}
+	return next.RequestURI(), nil
+}
+
+// match decodes an incident and reports whether it passes the filter. An
+// incident whose creation_date cannot be compared to the filter is left out
+// with a warning.
+func (f incidentFilter) match(raw json.RawMessage) (*client.Incident, []string, bool, diag.Diagnostics) {
+	var incident client.Incident
+	if err := json.Unmarshal(raw, &incident); err != nil {
+		return nil, nil, false, diag.FromErr(err)
+	}
+	var extra incidentTags
+	if err := json.Unmarshal(raw, &extra); err != nil {
+		return nil, nil, false, diag.FromErr(err)
+	}
+	tags := make([]string, len(extra.Tags))
+	for i, tag := range extra.Tags {
+		tags[i] = tag.TeamTag
+	}
+
+	switch {
+	case f.status != 0 && incident.Status != f.status,
+		f.teamID != "" && incident.ServiceObject.Team != f.teamID,
+		f.serviceID != "" && incident.Service != f.serviceID,
+		f.urgency != -1 && incident.Urgency != f.urgency,
+		f.priorityID != "" && incident.TeamPriority != f.priorityID:
+		return nil, nil, false, nil
+	}
+	for _, tag := range f.tags {
+		if !checkList(tag, tags) {
+			return nil, nil, false, nil
+		}
+	}
+	if !f.createdAfter.IsZero() || !f.createdBefore.IsZero() {
+		created, err := time.Parse(time.RFC3339Nano, incident.CreationDate)
+		if err != nil {
+			return nil, nil, false, diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Incident #%d left out", incident.IncidentNumber),
+				Detail:   fmt.Sprintf("Its creation_date %q cannot be compared to created_after and created_before: %s", incident.CreationDate, err),
+			}}
+		}
+		if !f.createdAfter.IsZero() && created.Before(f.createdAfter) ||
+			!f.createdBefore.IsZero() && !created.Before(f.createdBefore) {
+			return nil, nil, false, nil
+		}
+	}
+	return &incident, tags, true, nil
+}
+
 func dataSourceIncidentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	meta := m.(*Meta)
+	filter := newIncidentFilter(d)
+	maxResults := d.Get("max_results").(int)
 
 	var diags diag.Diagnostics
-	incidents, err := apiclient.Incidents.GetIncidents()
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	results := incidents.Results
-	items := make([]map[string]interface{}, len(results))
-	for i, result := range results {
-
-		item := make(map[string]interface{})
-		item["summary"] = result.Summary
-		item["incident_number"] = result.IncidentNumber
-		item["creation_date"] = result.CreationDate
-		item["status"] = result.Status
-		item["unique_id"] = result.UniqueID
-		item["service_object_name"] = result.ServiceObject.Name
-		item["service_object_unique_id"] = result.ServiceObject.UniqueID
-		item["service_object_creation_date"] = result.ServiceObject.CreationDate
-		item["service_object_status"] = result.ServiceObject.Status
-		item["service_object_team"] = result.ServiceObject.Team
-		item["service_object_summary"] = result.ServiceObject.Summary
-		item["service_object_description"] = result.ServiceObject.Description
-		item["service_object_acknowledgement_timeout"] = result.ServiceObject.AcknowledgmentTimeout
-		item["service_object_auto_resolve_timeout"] = result.ServiceObject.AutoResolveTimeouts
-		item["service_object_created_by"] = result.ServiceObject.CreatedBy
-		item["service_object_team_priority"] = result.ServiceObject.TeamPriority
-		item["service_object_task_template"] = result.ServiceObject.TaskTemplate
-		item["service_object_escalation_policy"] = result.ServiceObject.EscalationPolicy
-		item["service_object_team"] = result.ServiceObject.Team
-		item["service_object_sla"] = result.ServiceObject.SLA
-		item["service_object_collation_time"] = result.ServiceObject.CollationTime
-		item["service_object_collation"] = result.ServiceObject.Collation
-		item["team_priority"] = result.TeamPriority
-		item["team_priority_object"] = result.TeamPriorityObject
-		item["title"] = result.Title
-		item["incident_key"] = result.IncidentKey
-		item["service"] = result.Service
-		item["urgency"] = result.Urgency
-		items[i] = item
+	items := []map[string]interface{}{}
+	add := func(raw json.RawMessage) bool {
+		incident, tags, ok, matchDiags := filter.match(raw)
+		if ok {
+			items = append(items, flattenIncident(incident, tags))
+		}
+		diags = append(diags, matchDiags...)
+		return !matchDiags.HasError()
 	}
+
+	if number, ok := d.GetOk("number"); ok {
+		var raw json.RawMessage
+		err := meta.request(ctx, http.MethodGet, fmt.Sprintf("/api/incidents/%s/", number.(string)), nil, &raw)
+		if err != nil && !isNotFoundError(err) {
+			return apiErrorDiags(err)
+		}
+		if err == nil && !add(raw) {
+			return diags
+		}
+	} else {
+	pages:
+		for next := filter.query(); next != ""; {
+			var incidents incidentPage
+			if err := meta.request(ctx, http.MethodGet, next, nil, &incidents); err != nil {
+				return append(diags, apiErrorDiags(err)...)
+			}
+			for _, raw := range incidents.Results {
+				if !add(raw) {
+					return diags
+				}
+				if maxResults > 0 && len(items) == maxResults {
+					break pages
+				}
+			}
+			var err error
+			if next, err = nextIncidentPage(incidents); err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+		}
+	}
+
 	if err := d.Set("results", items); err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
-	d.SetId(time.Now().String())
+	tags := make([]string, len(filter.tags))
+	copy(tags, filter.tags)
+	sort.Strings(tags)
+	d.SetId(dataSourceID(
+		d.Get("number").(string),
+		strconv.Itoa(filter.status),
+		filter.teamID,
+		filter.serviceID,
+		d.Get("urgency").(string),
+		filter.priorityID,
+		strings.Join(tags, ","),
+		d.Get("created_after").(string),
+		d.Get("created_before").(string),
+		strconv.Itoa(maxResults),
+	))
 	return diags
+}
 
+func flattenIncident(result *client.Incident, tags []string) map[string]interface{} {
+	item := make(map[string]interface{})
+	item["summary"] = result.Summary
+	item["incident_number"] = result.IncidentNumber
+	item["creation_date"] = result.CreationDate
+	item["status"] = result.Status
+	item["unique_id"] = result.UniqueID
+	item["service_object_name"] = result.ServiceObject.Name
+	item["service_object_unique_id"] = result.ServiceObject.UniqueID
+	item["service_object_creation_date"] = result.ServiceObject.CreationDate
+	item["service_object_status"] = result.ServiceObject.Status
+	item["service_object_team"] = result.ServiceObject.Team
+	item["service_object_summary"] = result.ServiceObject.Summary
+	item["service_object_description"] = result.ServiceObject.Description
+	item["service_object_acknowledgement_timeout"] = result.ServiceObject.AcknowledgmentTimeout
+	item["service_object_auto_resolve_timeout"] = result.ServiceObject.AutoResolveTimeouts
+	item["service_object_created_by"] = result.ServiceObject.CreatedBy
+	item["service_object_team_priority"] = result.ServiceObject.TeamPriority
+	item["service_object_task_template"] = result.ServiceObject.TaskTemplate
+	item["service_object_escalation_policy"] = result.ServiceObject.EscalationPolicy
+	item["service_object_sla"] = result.ServiceObject.SLA
+	item["service_object_collation_time"] = result.ServiceObject.CollationTime
+	item["service_object_collation"] = result.ServiceObject.Collation
+	item["team_priority"] = result.TeamPriority
+	item["team_priority_object"] = result.TeamPriorityObject
+	item["title"] = result.Title
+	item["incident_key"] = result.IncidentKey
+	item["service"] = result.Service
+	item["urgency"] = result.Urgency
+	item["tags"] = tags
+	return item
+}
+
+func indexOf(s string, list []string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
 }
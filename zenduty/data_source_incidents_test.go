@@ -0,0 +1,201 @@
+package zenduty
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceIncidents_filter(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIncidentsConfig(name, username),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zenduty_incidents.acknowledged", "results.#", "1"),
+					resource.TestCheckResourceAttrPair("data.zenduty_incidents.acknowledged", "results.0.incident_number", "zenduty_incidents.test", "incident_number"),
+					resource.TestCheckResourceAttr("data.zenduty_incidents.acknowledged", "results.0.title", name),
+					resource.TestCheckResourceAttr("data.zenduty_incidents.resolved", "results.#", "0"),
+					resource.TestCheckResourceAttr("data.zenduty_incidents.future", "results.#", "0"),
+					resource.TestCheckResourceAttr("data.zenduty_incidents.number", "results.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceIncidentsConfig(name, username string) string {
+	return testAccIncidentsConfig(name, username, incidentAcknowledged) + `
+data "zenduty_incidents" "acknowledged" {
+  service_id  = zenduty_services.test.id
+  status      = 2
+  max_results = 10
+
+  depends_on = [zenduty_incidents.test]
+}
+
+data "zenduty_incidents" "resolved" {
+  service_id = zenduty_services.test.id
+  status     = 3
+
+  depends_on = [zenduty_incidents.test]
+}
+
+data "zenduty_incidents" "future" {
+  service_id    = zenduty_services.test.id
+  created_after = "2100-01-01T00:00:00Z"
+
+  depends_on = [zenduty_incidents.test]
+}
+
+data "zenduty_incidents" "number" {
+  number = zenduty_incidents.test.id
+}
+`
+}
+
+// TestDataSourceIncidentRead_pages serves the incident list in two pages and
+// checks that the data source sends its filters, follows next, and leaves out
+// an incident whose creation_date it cannot compare with a warning.
+func TestDataSourceIncidentRead_pages(t *testing.T) {
+	const serviceID = "3c3e8a52-9e2a-4c7d-a1e5-0a6f3a9b7c11"
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprintf(w, `{"next": "https://www.zenduty.com/api/incidents/?page=2&service_ids=%s", "results": [
+				{"incident_number": 1, "service": %q, "creation_date": "2030-01-07T10:00:00Z"},
+				{"incident_number": 2, "service": %q, "creation_date": "yesterday"}
+			]}`, serviceID, serviceID, serviceID)
+		case "2":
+			fmt.Fprintf(w, `{"next": null, "results": [
+				{"incident_number": 3, "service": %q, "creation_date": "2030-01-08T10:00:00Z"},
+				{"incident_number": 4, "service": "another", "creation_date": "2030-01-08T11:00:00Z"}
+			]}`, serviceID)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	meta, err := (&Config{Token: "token", BaseURL: server.URL}).Meta()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := dataSourceIncidents()
+	d := r.Data(nil)
+	d.Set("service_id", serviceID)
+	d.Set("created_after", "2030-01-01T00:00:00Z")
+	diags := r.ReadContext(context.Background(), d, meta)
+	if diags.HasError() {
+		t.Fatalf("reading incidents: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("diagnostics = %v, want a warning about incident #2", diags)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("requested %d pages, want 2", len(queries))
+	}
+	if got, want := queries[0], "from_date=2030-01-01T00%3A00%3A00Z&page=1&service_ids="+serviceID; got != want {
+		t.Errorf("query of the first page = %q, want %q", got, want)
+	}
+	results := d.Get("results").([]interface{})
+	var numbers []int
+	for _, result := range results {
+		numbers = append(numbers, result.(map[string]interface{})["incident_number"].(int))
+	}
+	if fmt.Sprint(numbers) != "[1 3]" {
+		t.Errorf("incident numbers = %v, want [1 3]", numbers)
+	}
+}
+
+// TestDataSourceIncidentRead_fakeAPI reads the incident list of the fake API,
+// which pages it fakePageSize incidents at a time.
+func TestDataSourceIncidentRead_fakeAPI(t *testing.T) {
+	api := newFakeAPI(t)
+	meta, err := (&Config{Token: api.token, BaseURL: api.URL}).Meta()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	const incidents = 2*fakePageSize + 1
+	for i := 0; i < incidents; i++ {
+		if err := meta.request(ctx, http.MethodPost, "/api/incidents/", map[string]interface{}{"title": testAccName()}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		maxResults int
+		want       string
+	}{
+		{0, "[1 2 3 4 5]"},
+		{3, "[1 2 3]"},
+		{fakePageSize, "[1 2]"},
+		{incidents + 1, "[1 2 3 4 5]"},
+	}
+	for _, c := range cases {
+		r := dataSourceIncidents()
+		d := r.Data(nil)
+		d.Set("max_results", c.maxResults)
+		if diags := r.ReadContext(ctx, d, meta); diags.HasError() {
+			t.Fatalf("max_results = %d: reading incidents: %v", c.maxResults, diags)
+		}
+		var numbers []int
+		for _, result := range d.Get("results").([]interface{}) {
+			numbers = append(numbers, result.(map[string]interface{})["incident_number"].(int))
+		}
+		if fmt.Sprint(numbers) != c.want {
+			t.Errorf("max_results = %d: incident numbers = %v, want %s", c.maxResults, numbers, c.want)
+		}
+	}
+}
+
+func TestIncidentFilterQuery(t *testing.T) {
+	const (
+		teamID     = "9b7e2c4a-1f3d-4e5b-8a6c-7d8e9f0a1b2c"
+		serviceID  = "3c3e8a52-9e2a-4c7d-a1e5-0a6f3a9b7c11"
+		priorityID = "5e6f7a8b-9c0d-4e1f-a2b3-c4d5e6f7a8b9"
+		tag1       = "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+		tag2       = "6f5e4d3c-2b1a-4f9e-8d7c-6b5a4f3e2d1c"
+	)
+	cases := []struct {
+		name string
+		args map[string]interface{}
+		want string
+	}{
+		{"none", nil, "/api/incidents/?page=1"},
+		{"status", map[string]interface{}{"status": incidentAcknowledged}, "/api/incidents/?page=1&status=2"},
+		{"low urgency", map[string]interface{}{"urgency": "low"}, "/api/incidents/?page=1&urgency=0"},
+		{"high urgency", map[string]interface{}{"urgency": "high"}, "/api/incidents/?page=1&urgency=1"},
+		{"ids", map[string]interface{}{"team_id": teamID, "service_id": serviceID, "priority_id": priorityID},
+			"/api/incidents/?page=1&priority_ids=" + priorityID + "&service_ids=" + serviceID + "&team_id=" + teamID},
+		{"tags", map[string]interface{}{"tags": []interface{}{tag1, tag2}}, "/api/incidents/?page=1&tag_ids=" + tag1 + "%2C" + tag2},
+		{"dates in UTC", map[string]interface{}{"created_after": "2030-01-07T05:30:00+05:30", "created_before": "2030-02-01T00:00:00Z"},
+			"/api/incidents/?from_date=2030-01-07T00%3A00%3A00Z&page=1&to_date=2030-02-01T00%3A00%3A00Z"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := dataSourceIncidents().Data(nil)
+			for k, v := range c.args {
+				if err := d.Set(k, v); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := newIncidentFilter(d).query(); got != c.want {
+				t.Errorf("query() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package zenduty
 
 import (
 	"context"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -89,7 +88,10 @@ func dataSourceIntegrations() *schema.Resource {
 }
 
 func dataSourceIncidentReads(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	serviceID := d.Get("service_id").(string)
@@ -131,7 +133,7 @@ func dataSourceIncidentReads(ctx context.Context, d *schema.ResourceData, m inte
 		if err := d.Set("integrations", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(teamID, serviceID, integrationID))
 		return diags
 
 	} else {
@@ -173,7 +175,7 @@ func dataSourceIncidentReads(ctx context.Context, d *schema.ResourceData, m inte
 		if err := d.Set("integrations", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(teamID, serviceID, integrationID))
 		return diags
 
 	}
@@ -2,7 +2,6 @@ package zenduty
 
 import (
 	"context"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -79,14 +78,17 @@ func dataSourceMaintenanceWindow() *schema.Resource {
 }
 
 func dataSourceManintenanceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	teamID := d.Get("team_id").(string)
 
 	var diags diag.Diagnostics
 
 	maintenances, err := apiclient.MaintenanceWindow.GetMaintenanceWindows(teamID)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	items := make([]map[string]interface{}, len(maintenances))
 
@@ -115,7 +117,7 @@ func dataSourceManintenanceRead(ctx context.Context, d *schema.ResourceData, m i
 	if err := d.Set("maintenance_windows", items); err != nil {
 		return diag.FromErr(err)
 	}
-	d.SetId(time.Now().String())
+	d.SetId(dataSourceID(teamID))
 
 	return diags
 
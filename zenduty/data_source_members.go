@@ -2,7 +2,6 @@ package zenduty
 
 import (
 	"context"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -56,7 +55,10 @@ func dataSourceMembers() *schema.Resource {
 }
 
 func dataSourceMembersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	memberID := d.Get("member_id").(string)
@@ -66,7 +68,7 @@ func dataSourceMembersRead(ctx context.Context, d *schema.ResourceData, m interf
 		var diags diag.Diagnostics
 		member, err := apiclient.Members.GetTeamMembersByID(teamID, memberID)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 
 		items := make([]map[string]interface{}, 1)
@@ -85,7 +87,7 @@ func dataSourceMembersRead(ctx context.Context, d *schema.ResourceData, m interf
 		if err := d.Set("members", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(teamID, memberID))
 
 		return diags
 	} else {
@@ -94,7 +96,7 @@ func dataSourceMembersRead(ctx context.Context, d *schema.ResourceData, m interf
 
 		members, err := apiclient.Members.GetTeamMembers(teamID)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 
 		items := make([]map[string]interface{}, len(members))
@@ -113,7 +115,7 @@ func dataSourceMembersRead(ctx context.Context, d *schema.ResourceData, m interf
 			items[i] = item
 		}
 
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(teamID, memberID))
 
 		if err := d.Set("members", items); err != nil {
 			return diag.FromErr(err)
@@ -0,0 +1,248 @@
+package zenduty
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Target types of escalation policy rules.
+const (
+	espTargetSchedule = 1
+	espTargetUser     = 2
+)
+
+func dataSourceOncall() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceOncallRead,
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: ValidateUUID(),
+			},
+			"schedule_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ExactlyOneOf:     []string{"schedule_id", "escalation_policy_id"},
+				ValidateDiagFunc: ValidateUUID(),
+			},
+			"escalation_policy_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ExactlyOneOf:     []string{"schedule_id", "escalation_policy_id"},
+				ValidateDiagFunc: ValidateUUID(),
+			},
+			"at": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"until": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"layers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"until": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"overrides": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"until": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"rules": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"position": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"delay": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"users": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOncallRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	teamID := d.Get("team_id").(string)
+	scheduleID := d.Get("schedule_id").(string)
+	espID := d.Get("escalation_policy_id").(string)
+	at := time.Now().UTC()
+	if v := d.Get("at").(string); v != "" {
+		at, _ = time.Parse(time.RFC3339, v)
+	}
+
+	schedules := make(map[string]oncallState)
+	scheduleAt := func(id string) (oncallState, diag.Diagnostics) {
+		if state, ok := schedules[id]; ok {
+			return state, nil
+		}
+		schedule, err := apiclient.Schedules.GetScheduleByID(teamID, id)
+		if err != nil {
+			return oncallState{}, apiErrorDiags(err)
+		}
+		s, err := newOncallSchedule(schedule.TimeZone, schedule.Layers, schedule.Overrides)
+		if err != nil {
+			return oncallState{}, diag.Errorf("schedule %s: %s", id, err)
+		}
+		state := s.at(at)
+		schedules[id] = state
+		return state, nil
+	}
+
+	var diags diag.Diagnostics
+	var users []string
+	var until time.Time
+	var layers, overrides, rules []map[string]interface{}
+	if scheduleID != "" {
+		state, scheduleDiags := scheduleAt(scheduleID)
+		diags = append(diags, scheduleDiags...)
+		if diags.HasError() {
+			return diags
+		}
+		users = state.users
+		until = state.until
+		for _, layer := range state.layers {
+			layers = append(layers, map[string]interface{}{
+				"name":  layer.name,
+				"user":  layer.user,
+				"until": formatOncallTime(layer.until),
+			})
+		}
+		for _, override := range state.overrides {
+			overrides = append(overrides, map[string]interface{}{
+				"name":  override.name,
+				"user":  override.user,
+				"until": formatOncallTime(override.end),
+			})
+		}
+	} else {
+		esp, err := apiclient.Esp.GetEscalationPolicyByID(teamID, espID)
+		if err != nil {
+			return apiErrorDiags(err)
+		}
+		sort.SliceStable(esp.Rules, func(i, j int) bool {
+			return esp.Rules[i].Position < esp.Rules[j].Position
+		})
+		for i, rule := range esp.Rules {
+			var ruleUsers []string
+			for _, target := range rule.Targets {
+				switch target.TargetType {
+				case espTargetUser:
+					ruleUsers = appendUnique(ruleUsers, target.TargetID)
+				case espTargetSchedule:
+					state, scheduleDiags := scheduleAt(target.TargetID)
+					diags = append(diags, scheduleDiags...)
+					if diags.HasError() {
+						return diags
+					}
+					for _, user := range state.users {
+						ruleUsers = appendUnique(ruleUsers, user)
+					}
+					until = earliest(until, state.until)
+				default:
+					// Targets the provider does not know, such as those of
+					// later API versions, notify no one it can name.
+					diags = append(diags, diag.Diagnostic{
+						Severity: diag.Warning,
+						Summary:  "Unknown escalation target skipped",
+						Detail:   fmt.Sprintf("Rule %d of escalation policy %s has a target %s of unknown target_type %d. Its users are not in users or rules.", rule.Position, espID, target.TargetID, target.TargetType),
+					})
+				}
+			}
+			// The users of the first rule are the ones notified first.
+			if i == 0 {
+				users = ruleUsers
+			}
+			rules = append(rules, map[string]interface{}{
+				"position": rule.Position,
+				"delay":    rule.Delay,
+				"users":    ruleUsers,
+			})
+		}
+	}
+
+	if err := d.Set("users", users); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	d.Set("until", formatOncallTime(until))
+	if err := d.Set("layers", layers); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if err := d.Set("overrides", overrides); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if err := d.Set("rules", rules); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	d.SetId(dataSourceID(teamID, scheduleID, espID, d.Get("at").(string)))
+	return diags
+}
+
+// formatOncallTime formats t as an RFC 3339 timestamp in UTC, or as an empty
+// string when it is zero.
+func formatOncallTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
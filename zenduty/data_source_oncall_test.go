@@ -0,0 +1,84 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceOncall_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceOncallConfig(name, username),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zenduty_oncall.business_hours", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.zenduty_oncall.business_hours", "users.0", username),
+					resource.TestCheckResourceAttr("data.zenduty_oncall.business_hours", "until", "2030-01-08T12:30:00Z"),
+					resource.TestCheckResourceAttr("data.zenduty_oncall.business_hours", "layers.#", "1"),
+					resource.TestCheckResourceAttr("data.zenduty_oncall.business_hours", "layers.0.name", "Business hours"),
+					resource.TestCheckResourceAttr("data.zenduty_oncall.business_hours", "layers.0.user", username),
+					resource.TestCheckResourceAttr("data.zenduty_oncall.evening", "users.#", "0"),
+					resource.TestCheckResourceAttr("data.zenduty_oncall.evening", "layers.0.user", ""),
+					resource.TestCheckResourceAttr("data.zenduty_oncall.evening", "until", "2030-01-09T03:30:00Z"),
+					resource.TestCheckResourceAttr("data.zenduty_oncall.esp", "rules.#", "2"),
+					resource.TestCheckResourceAttr("data.zenduty_oncall.esp", "rules.0.users.#", "0"),
+					resource.TestCheckResourceAttr("data.zenduty_oncall.esp", "rules.1.users.0", username),
+					resource.TestCheckResourceAttr("data.zenduty_oncall.esp", "users.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// testAccDataSourceOncallConfig looks up who is on call on the schedule of
+// testAccSchedulesConfig, during and after business hours, and on an
+// escalation policy paging the schedule first and username next.
+func testAccDataSourceOncallConfig(name, username string) string {
+	return testAccSchedulesConfig(name, username, 86400) + fmt.Sprintf(`
+resource "zenduty_esp" "test" {
+  name    = %[1]q
+  summary = "Created by acceptance tests"
+  team_id = zenduty_teams.test.id
+
+  rules {
+    delay = 0
+    targets {
+      target_type = 1
+      target_id   = zenduty_schedules.test.id
+    }
+  }
+
+  rules {
+    delay = 5
+    targets {
+      target_type = 2
+      target_id   = %[2]q
+    }
+  }
+}
+
+data "zenduty_oncall" "business_hours" {
+  team_id     = zenduty_teams.test.id
+  schedule_id = zenduty_schedules.test.id
+  at          = "2030-01-08T05:00:00Z"
+}
+
+data "zenduty_oncall" "evening" {
+  team_id     = zenduty_teams.test.id
+  schedule_id = zenduty_schedules.test.id
+  at          = "2030-01-08T13:00:00Z"
+}
+
+data "zenduty_oncall" "esp" {
+  team_id              = zenduty_teams.test.id
+  escalation_policy_id = zenduty_esp.test.id
+  at                   = "2030-01-08T13:00:00Z"
+}
+`, name, username)
+}
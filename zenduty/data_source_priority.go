@@ -54,7 +54,10 @@ func dataSourcePriorities() *schema.Resource {
 }
 
 func dataSourcePriorityRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	var diags diag.Diagnostics
 
@@ -62,7 +65,7 @@ func dataSourcePriorityRead(ctx context.Context, d *schema.ResourceData, m inter
 
 	priorities, err := apiclient.Priority.GetPriority(teamID)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	items := make([]map[string]interface{}, len(priorities))
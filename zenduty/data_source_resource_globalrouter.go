@@ -2,7 +2,6 @@ package zenduty
 
 import (
 	"context"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -56,14 +55,17 @@ func dataSourceGlobalRouter() *schema.Resource {
 }
 
 func dataSourceGlobalRouterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	routerID := d.Get("router_id").(string)
 	if routerID != "" {
 		var diags diag.Diagnostics
 		router, err := apiclient.GlobalRouter.GetGlobalRouter(routerID)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 		items := make([]map[string]interface{}, 1)
 		item := make(map[string]interface{})
@@ -76,7 +78,7 @@ func dataSourceGlobalRouterRead(ctx context.Context, d *schema.ResourceData, m i
 		if err := d.Set("global_routers", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(routerID))
 
 		return diags
 	} else {
@@ -84,7 +86,7 @@ func dataSourceGlobalRouterRead(ctx context.Context, d *schema.ResourceData, m i
 
 		routers, err := apiclient.GlobalRouter.GetGlobalRouters()
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 
 		items := make([]map[string]interface{}, len(routers))
@@ -101,7 +103,7 @@ func dataSourceGlobalRouterRead(ctx context.Context, d *schema.ResourceData, m i
 		if err := d.Set("global_routers", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(routerID))
 
 		return diags
 	}
@@ -53,7 +53,10 @@ func dataSourceRoles() *schema.Resource {
 }
 
 func dataSourceOrderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	var diags diag.Diagnostics
 
@@ -61,7 +64,7 @@ func dataSourceOrderRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 	roles, err := apiclient.Roles.GetRoles(teamID)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	items := make([]map[string]interface{}, len(roles))
@@ -0,0 +1,95 @@
+package zenduty
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceScheduleICal() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceScheduleICalRead,
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: ValidateUUID(),
+			},
+			"schedule_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: ValidateUUID(),
+			},
+			"start": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateTimestamp(),
+			},
+			"days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      28,
+				ValidateFunc: validation.IntBetween(1, 366),
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ical": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceScheduleICalRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	teamID := d.Get("team_id").(string)
+	scheduleID := d.Get("schedule_id").(string)
+	schedule, err := apiclient.Schedules.GetScheduleByID(teamID, scheduleID)
+	if err != nil {
+		return apiErrorDiags(err)
+	}
+	loc, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return diag.Errorf("schedule %s has an invalid time_zone: %s", scheduleID, err)
+	}
+	from := time.Now().Truncate(time.Minute)
+	if v := d.Get("start").(string); v != "" {
+		if from, err = parseTimestamp(v, loc); err != nil {
+			return attributeDiag(cty.GetAttrPath("start"), err.Error())
+		}
+	}
+	to := from.AddDate(0, 0, d.Get("days").(int))
+
+	s, err := newOncallSchedule(schedule.TimeZone, schedule.Layers, schedule.Overrides)
+	if err != nil {
+		return diag.Errorf("schedule %s: %s", scheduleID, err)
+	}
+	calendar := icalCalendar{
+		scheduleID: scheduleID,
+		name:       schedule.Name,
+		timeZone:   schedule.TimeZone,
+		stamp:      s.start(),
+	}
+	user := d.Get("user").(string)
+	for _, shift := range s.shifts(from, to) {
+		if user == "" || shift.user == user {
+			calendar.shifts = append(calendar.shifts, shift)
+		}
+	}
+
+	d.Set("ical", calendar.String())
+	d.SetId(dataSourceID(teamID, scheduleID, d.Get("start").(string), fmt.Sprint(d.Get("days")), user))
+	return nil
+}
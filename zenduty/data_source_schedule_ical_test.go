@@ -0,0 +1,65 @@
+package zenduty
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataSourceScheduleICal_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchedulesConfig(name, username, 86400) + `
+data "zenduty_schedule_ical" "test" {
+  team_id     = zenduty_teams.test.id
+  schedule_id = zenduty_schedules.test.id
+  start       = "2030-01-07 00:00"
+  days        = 2
+}
+
+data "zenduty_schedule_ical" "nobody" {
+  team_id     = zenduty_teams.test.id
+  schedule_id = zenduty_schedules.test.id
+  start       = "2030-01-07 00:00"
+  days        = 2
+  user        = "nobody"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckICalEvents("data.zenduty_schedule_ical.test", 2),
+					resource.TestMatchResourceAttr("data.zenduty_schedule_ical.test", "ical", regexp.MustCompile(`DTSTART:20300107T033000Z\r\nDTEND:20300107T123000Z\r\n`)),
+					resource.TestMatchResourceAttr("data.zenduty_schedule_ical.test", "ical", regexp.MustCompile(`DTSTART:20300108T033000Z\r\nDTEND:20300108T123000Z\r\n`)),
+					testAccCheckICalEvents("data.zenduty_schedule_ical.nobody", 0),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckICalEvents checks that the calendar of the zenduty_schedule_ical
+// data source name has count events.
+func testAccCheckICalEvents(name string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("not found: %s", name)
+		}
+		ical := rs.Primary.Attributes["ical"]
+		if !strings.HasPrefix(ical, "BEGIN:VCALENDAR\r\n") {
+			return fmt.Errorf("%s: ical is not a calendar: %q", name, ical)
+		}
+		if n := strings.Count(ical, "BEGIN:VEVENT\r\n"); n != count {
+			return fmt.Errorf("%s: calendar has %d events, want %d", name, n, count)
+		}
+		return nil
+	}
+}
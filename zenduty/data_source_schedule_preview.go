@@ -0,0 +1,109 @@
+package zenduty
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceSchedulePreview() *schema.Resource {
+	layers := scheduleLayersSchema()
+	layers.Required = true
+	layers.Optional = false
+	layers.MinItems = 1
+
+	return &schema.Resource{
+		ReadContext: dataSourceSchedulePreviewRead,
+		Schema: map[string]*schema.Schema{
+			"time_zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) ([]string, []error) {
+					if _, err := time.LoadLocation(v.(string)); err != nil {
+						return nil, []error{fmt.Errorf("%s: %w", k, err)}
+					}
+					return nil, nil
+				},
+			},
+			"layers":    layers,
+			"overrides": scheduleOverridesSchema(),
+			"start": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateTimestamp(),
+			},
+			"days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      7,
+				ValidateFunc: validation.IntBetween(1, 366),
+			},
+			"shifts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"layer": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"start": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"end": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"override": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSchedulePreviewRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	timeZone := d.Get("time_zone").(string)
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("time_zone"), err.Error())
+	}
+	from, err := parseTimestamp(d.Get("start").(string), loc)
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("start"), err.Error())
+	}
+	to := from.AddDate(0, 0, d.Get("days").(int))
+
+	schedule, diags := scheduleFromConfig(ctx, d, timeZone)
+	if diags.HasError() {
+		return diags
+	}
+	var shifts []map[string]interface{}
+	for _, shift := range schedule.shifts(from, to) {
+		shifts = append(shifts, map[string]interface{}{
+			"layer":    shift.layer,
+			"user":     shift.user,
+			"start":    shift.start.In(loc).Format(localTimestampFormat),
+			"end":      shift.end.In(loc).Format(localTimestampFormat),
+			"override": shift.override,
+		})
+	}
+	if err := d.Set("shifts", shifts); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(dataSourceID(timeZone, d.Get("start").(string), fmt.Sprint(d.Get("days")), fmt.Sprint(d.Get("layers")), fmt.Sprint(d.Get("overrides"))))
+	return diags
+}
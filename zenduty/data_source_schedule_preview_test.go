@@ -0,0 +1,52 @@
+package zenduty
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSchedulePreview_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSchedulePreviewConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zenduty_schedule_preview.test", "shifts.#", "2"),
+					resource.TestCheckResourceAttr("data.zenduty_schedule_preview.test", "shifts.0.layer", "Business hours"),
+					resource.TestCheckResourceAttr("data.zenduty_schedule_preview.test", "shifts.0.user", "alice"),
+					resource.TestCheckResourceAttr("data.zenduty_schedule_preview.test", "shifts.0.start", "2030-01-07 09:00"),
+					resource.TestCheckResourceAttr("data.zenduty_schedule_preview.test", "shifts.0.end", "2030-01-07 18:00"),
+					resource.TestCheckResourceAttr("data.zenduty_schedule_preview.test", "shifts.1.user", "bob"),
+					resource.TestCheckResourceAttr("data.zenduty_schedule_preview.test", "shifts.1.start", "2030-01-08 09:00"),
+					resource.TestCheckResourceAttr("data.zenduty_schedule_preview.test", "shifts.1.end", "2030-01-08 18:00"),
+					resource.TestCheckResourceAttr("data.zenduty_schedule_preview.test", "shifts.1.override", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceSchedulePreviewConfig = `
+data "zenduty_schedule_preview" "test" {
+  time_zone = "Asia/Kolkata"
+  start     = "2030-01-07 00:00"
+  days      = 2
+
+  layers {
+    name                = "Business hours"
+    shift_length        = 86400
+    rotation_start_time = "2030-01-07 09:00"
+    users               = ["alice", "bob"]
+    restriction_type    = 1
+
+    restrictions {
+      duration          = 32400
+      start_day_of_week = 7
+      start_time_of_day = "09:00:00"
+    }
+  }
+}
+`
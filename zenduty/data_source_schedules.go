@@ -2,7 +2,6 @@ package zenduty
 
 import (
 	"context"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -171,7 +170,10 @@ func dataSourceSchedules() *schema.Resource {
 }
 
 func dataSourceScheduleReads(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	scheduleID := d.Get("schedule_id").(string)
@@ -180,7 +182,7 @@ func dataSourceScheduleReads(ctx context.Context, d *schema.ResourceData, m inte
 	if scheduleID != "" {
 		schedule, err := apiclient.Schedules.GetScheduleByID(teamID, scheduleID)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 		items := make([]map[string]interface{}, 1)
 		item := make(map[string]interface{})
@@ -244,14 +246,14 @@ func dataSourceScheduleReads(ctx context.Context, d *schema.ResourceData, m inte
 		if err := d.Set("schedules", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(teamID, scheduleID))
 
 		return diags
 	} else {
 
 		schedules, err := apiclient.Schedules.GetSchedules(teamID)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 		items := make([]map[string]interface{}, len(schedules))
 		for i, schedule := range schedules {
@@ -316,7 +318,7 @@ func dataSourceScheduleReads(ctx context.Context, d *schema.ResourceData, m inte
 		if err := d.Set("schedules", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(teamID, scheduleID))
 
 		return diags
 	}
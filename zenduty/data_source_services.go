@@ -2,7 +2,6 @@ package zenduty
 
 import (
 	"context"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -102,7 +101,10 @@ func dataSourceServices() *schema.Resource {
 }
 
 func dataSourceServicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	id := d.Get("service_id").(string)
@@ -113,7 +115,7 @@ func dataSourceServicesRead(ctx context.Context, d *schema.ResourceData, m inter
 	if id != "" {
 		service, err := apiclient.Services.GetServicesByID(teamID, id)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 		items := make([]map[string]interface{}, 1)
 
@@ -139,14 +141,14 @@ func dataSourceServicesRead(ctx context.Context, d *schema.ResourceData, m inter
 		if err := d.Set("services", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(teamID, id))
 		return diags
 	} else {
 
 		// id := d.Get("id").(string)
 		services, err := apiclient.Services.GetServices(teamID)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 		items := make([]map[string]interface{}, len(services))
 		for i, service := range services {
@@ -173,7 +175,7 @@ func dataSourceServicesRead(ctx context.Context, d *schema.ResourceData, m inter
 		if err := d.Set("services", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(teamID, id))
 
 		return diags
 	}
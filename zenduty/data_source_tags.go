@@ -50,7 +50,10 @@ func dataSourceTags() *schema.Resource {
 }
 
 func dataSourceTagsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	var diags diag.Diagnostics
 
@@ -58,7 +61,7 @@ func dataSourceTagsRead(ctx context.Context, d *schema.ResourceData, m interface
 
 	tags, err := apiclient.Tags.GetTags(teamID)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	items := make([]map[string]interface{}, len(tags))
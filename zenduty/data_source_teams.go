@@ -2,7 +2,6 @@ package zenduty
 
 import (
 	"context"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -111,14 +110,17 @@ func dataSourceTeams() *schema.Resource {
 }
 
 func dataSourceTeamReads(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	if teamID != "" {
 		var diags diag.Diagnostics
 		team, err := apiclient.Teams.GetTeamByID(teamID)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 		items := make([]map[string]interface{}, 1)
 		item := make(map[string]interface{})
@@ -161,7 +163,7 @@ func dataSourceTeamReads(ctx context.Context, d *schema.ResourceData, m interfac
 		if err := d.Set("teams", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(teamID))
 
 		return diags
 
@@ -171,7 +173,7 @@ func dataSourceTeamReads(ctx context.Context, d *schema.ResourceData, m interfac
 
 		teams, err := apiclient.Teams.GetTeams()
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err)
 		}
 		items := make([]map[string]interface{}, len(teams))
 		for i, team := range teams {
@@ -214,7 +216,7 @@ func dataSourceTeamReads(ctx context.Context, d *schema.ResourceData, m interfac
 		if err := d.Set("teams", items); err != nil {
 			return diag.FromErr(err)
 		}
-		d.SetId(time.Now().String())
+		d.SetId(dataSourceID(teamID))
 
 		return diags
 	}
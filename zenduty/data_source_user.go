@@ -3,7 +3,6 @@ package zenduty
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -51,7 +50,10 @@ func dataSourceUsers() *schema.Resource {
 }
 
 func dataSourceUserReads(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	email := d.Get("email").(string)
 
@@ -59,7 +61,7 @@ func dataSourceUserReads(ctx context.Context, d *schema.ResourceData, m interfac
 
 	users, err := apiclient.Users.GetUsers(email)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	if len(users) == 0 {
 		return diag.FromErr(fmt.Errorf("no users found with email %s", email))
@@ -78,7 +80,7 @@ func dataSourceUserReads(ctx context.Context, d *schema.ResourceData, m interfac
 	if err := d.Set("users", items); err != nil {
 		return diag.FromErr(err)
 	}
-	d.SetId(time.Now().String())
+	d.SetId(dataSourceID(email))
 
 	return diags
 }
@@ -0,0 +1,500 @@
+package zenduty
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeAPI is an in-memory stand-in for the Zenduty REST API, so acceptance
+// tests can run full plan/apply/import/destroy cycles without network access.
+//
+// It knows few of the individual endpoints. A POST to a path creates an item
+// in the collection at that path, GET, PUT, PATCH and DELETE on
+// <collection>/<id>/ act on that item, and GET on the collection lists its
+// items, a page at a time for paginated collections. A PUT to a path that
+// holds no item stores it as a singleton, which covers settings such as team
+// permissions. Below the prefixes of fakeRoutes, only the declared endpoints
+// exist, so a request to a wrong path fails as it would against the API. Deleting an item deletes the
+// items nested below it, the way deleting a team deletes its services.
+// Fields the API computes on create are filled in by fakeCollections.
+type fakeAPI struct {
+	*httptest.Server
+	token string
+
+	mu          sync.Mutex
+	items       map[string]map[string]interface{}
+	order       []string
+	collections map[string]bool
+	incidents   int
+}
+
+// fakeCollection describes a collection whose items need more than a
+// unique_id, matched on the last segment of the collection path.
+type fakeCollection struct {
+	// id returns the value that identifies an item in URLs. Defaults to
+	// its unique_id.
+	id func(item map[string]interface{}) interface{}
+	// paginated collections are listed fakePageSize items at a time, as
+	// {"count", "next", "previous", "results"} instead of a plain array.
+	paginated bool
+	// create fills in the fields the API computes for a new item.
+	create func(f *fakeAPI, item map[string]interface{})
+	// normalize reshapes a created or updated item the way the API
+	// returns it, for fields that are sent as an ID but read back as an
+	// object.
+	normalize func(item map[string]interface{})
+	// nested lists the fields of an item that the API keeps as a
+	// collection below the item, the way the overrides of a schedule are
+	// also managed at <schedule>/overrides/. The field is read back as the
+	// items of that collection, and a create or update that sets it
+	// replaces them.
+	nested []string
+}
+
+var fakeCollections = map[string]fakeCollection{
+	"integrations": {
+		create: func(f *fakeAPI, item map[string]interface{}) {
+			key := uuid.NewString()
+			item["integration_key"] = key
+			item["webhook_url"] = fmt.Sprintf("%s/api/events/%s/", f.URL, key)
+		},
+	},
+	"incidents": {
+		id: func(item map[string]interface{}) interface{} {
+			return item["incident_number"]
+		},
+		paginated: true,
+		create: func(f *fakeAPI, item map[string]interface{}) {
+			f.incidents++
+			item["incident_number"] = f.incidents
+			if status, _ := item["status"].(float64); status == 0 {
+				item["status"] = 1
+			}
+			if assignee, _ := item["assigned_to"].(string); assignee == "" {
+				item["assigned_to"] = item["user"]
+			}
+			if urgency, _ := item["urgency"].(float64); urgency == 0 {
+				item["urgency"] = 1
+			}
+		},
+	},
+	"users": {
+		id: func(item map[string]interface{}) interface{} {
+			user, _ := item["user"].(map[string]interface{})
+			return user["username"]
+		},
+		create: func(f *fakeAPI, item map[string]interface{}) {
+			user, ok := item["user"].(map[string]interface{})
+			if !ok {
+				user = map[string]interface{}{}
+				item["user"] = user
+			}
+			if _, ok := user["username"]; !ok {
+				user["username"] = fakeUsername()
+			}
+		},
+		// Users are created with their profile nested under "user" but
+		// updated with a flat one, while the role is always kept at the
+		// top level.
+		normalize: func(item map[string]interface{}) {
+			user, _ := item["user"].(map[string]interface{})
+			for _, k := range []string{"first_name", "last_name", "email"} {
+				if v, ok := item[k]; ok {
+					user[k] = v
+					delete(item, k)
+				}
+			}
+			if role, ok := user["role"]; ok {
+				if _, ok := item["role"]; !ok {
+					item["role"] = role
+				}
+				delete(user, "role")
+			}
+		},
+	},
+	"members": {
+		normalize: func(item map[string]interface{}) {
+			if username, ok := item["user"].(string); ok {
+				item["user"] = map[string]interface{}{"username": username}
+			}
+		},
+	},
+	// Layers are given a unique_id when they are created, and keep it when
+	// the schedule is updated with it.
+	"schedules": {
+		nested: []string{"overrides"},
+		normalize: func(item map[string]interface{}) {
+			layers, _ := item["layers"].([]interface{})
+			for _, layer := range layers {
+				layer, ok := layer.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if id, _ := layer["unique_id"].(string); id == "" {
+					layer["unique_id"] = uuid.NewString()
+				}
+			}
+		},
+	},
+	"rules": {
+		normalize: func(item map[string]interface{}) {
+			actions, _ := item["actions"].([]interface{})
+			for _, action := range actions {
+				action, ok := action.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if integration, ok := action["integration"].(string); ok && integration != "" {
+					action["integration_object"] = map[string]interface{}{"unique_id": integration}
+				}
+			}
+		},
+	},
+}
+
+// fakeUsername returns a username in the format of the Zenduty API, a UUID
+// cut short after the first character of its last group.
+func fakeUsername() string {
+	return uuid.NewString()[:25]
+}
+
+// newFakeAPI starts a fake API that is shut down when the test ends.
+func newFakeAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+	f := &fakeAPI{
+		token:       uuid.NewString(),
+		items:       map[string]map[string]interface{}{},
+		collections: map[string]bool{},
+	}
+	f.Server = httptest.NewServer(f)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Token "+f.token {
+		writeFakeJSON(w, http.StatusUnauthorized, map[string]interface{}{"detail": "Invalid token."})
+		return
+	}
+	if !fakeRouteDeclared(r.URL.Path) {
+		writeFakeJSON(w, http.StatusNotFound, map[string]interface{}{"detail": "Not found."})
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Method == http.MethodPost || r.Method == http.MethodPut || r.Method == http.MethodPatch {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeFakeJSON(w, http.StatusBadRequest, map[string]interface{}{"detail": "JSON parse error - " + err.Error()})
+			return
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p := strings.TrimSuffix(r.URL.Path, "/")
+	item, isItem := f.items[p]
+	switch {
+	case r.Method == http.MethodGet && isItem:
+		writeFakeJSON(w, http.StatusOK, f.withNested(p, item))
+	case r.Method == http.MethodGet && f.isCollection(p):
+		if !fakeCollections[path.Base(p)].paginated {
+			writeFakeJSON(w, http.StatusOK, f.list(p))
+			return
+		}
+		page, ok := f.page(r, f.list(p))
+		if !ok {
+			writeFakeJSON(w, http.StatusNotFound, map[string]interface{}{"detail": "Invalid page."})
+			return
+		}
+		writeFakeJSON(w, http.StatusOK, page)
+	case r.Method == http.MethodPost:
+		item, err := f.create(p, body)
+		if err != nil {
+			writeFakeJSON(w, http.StatusBadRequest, map[string]interface{}{"detail": err.Error()})
+			return
+		}
+		writeFakeJSON(w, http.StatusCreated, item)
+	case (r.Method == http.MethodPut || r.Method == http.MethodPatch) && isItem:
+		for k, v := range body {
+			item[k] = v
+		}
+		if normalize := fakeCollections[path.Base(path.Dir(p))].normalize; normalize != nil {
+			normalize(item)
+		}
+		if err := f.replaceNested(p, body); err != nil {
+			writeFakeJSON(w, http.StatusBadRequest, map[string]interface{}{"detail": err.Error()})
+			return
+		}
+		writeFakeJSON(w, http.StatusOK, f.withNested(p, item))
+	case r.Method == http.MethodPut || r.Method == http.MethodPatch:
+		f.store(p, body)
+		writeFakeJSON(w, http.StatusOK, body)
+	case r.Method == http.MethodDelete && isItem:
+		f.delete(p)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeJSON(w, http.StatusNotFound, map[string]interface{}{"detail": "Not found."})
+	}
+}
+
+// fakeRoutes declares the endpoints that exist below a prefix. Requests to
+// other paths below it are answered with a 404, instead of creating a
+// collection of their own.
+var fakeRoutes = map[string][]*regexp.Regexp{
+	"/api/incidents/": {
+		regexp.MustCompile(`^/api/incidents/$`),
+		regexp.MustCompile(`^/api/incidents/[0-9]+/$`),
+		regexp.MustCompile(`^/api/incidents/[0-9]+/note/$`),
+		regexp.MustCompile(`^/api/incidents/[0-9]+/note/[0-9a-f-]{36}/$`),
+		regexp.MustCompile(`^/api/incidents/[0-9]+/roles/$`),
+		regexp.MustCompile(`^/api/incidents/[0-9]+/roles/[0-9a-f-]{36}/$`),
+	},
+}
+
+// fakeRouteDeclared reports whether the endpoint at p exists, which it does
+// unless p is below a prefix of fakeRoutes and matches none of its routes.
+func fakeRouteDeclared(p string) bool {
+	for prefix, routes := range fakeRoutes {
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		for _, route := range routes {
+			if route.MatchString(p) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// isCollection reports whether p names a collection. Paths that were posted
+// to are collections; so is any path whose last segment does not look like
+// an item ID, which lets an empty collection be listed.
+func (f *fakeAPI) isCollection(p string) bool {
+	if f.collections[p] {
+		return true
+	}
+	return !fakeItemID.MatchString(path.Base(p))
+}
+
+// fakeItemID matches the IDs the API puts in URLs: UUIDs, usernames (UUIDs
+// cut short in their last group) and incident numbers.
+var fakeItemID = regexp.MustCompile(`^([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{1,12}|[0-9]+)$`)
+
+func (f *fakeAPI) list(collection string) []map[string]interface{} {
+	results := []map[string]interface{}{}
+	for _, p := range f.order {
+		if path.Dir(p) == collection {
+			results = append(results, f.withNested(p, f.items[p]))
+		}
+	}
+	return results
+}
+
+// fakePageSize is the number of items on a page of a paginated collection.
+// It is small so that tests with a few items already read several pages.
+const fakePageSize = 2
+
+// page returns the page of results asked for by the page query parameter of
+// r, 1 when unset. As with the API, next and previous are absolute URLs that
+// keep the other query parameters, and there is no page past the last one.
+// The other query parameters do not filter the results.
+func (f *fakeAPI) page(r *http.Request, results []map[string]interface{}) (map[string]interface{}, bool) {
+	query := r.URL.Query()
+	page := 1
+	if v := query.Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return nil, false
+		}
+		page = n
+	}
+	start := (page - 1) * fakePageSize
+	if start > 0 && start >= len(results) {
+		return nil, false
+	}
+	end := start + fakePageSize
+	if end > len(results) {
+		end = len(results)
+	}
+
+	link := func(page int) interface{} {
+		query.Set("page", strconv.Itoa(page))
+		return f.URL + r.URL.Path + "?" + query.Encode()
+	}
+	var next, previous interface{}
+	if end < len(results) {
+		next = link(page + 1)
+	}
+	if page > 1 {
+		previous = link(page - 1)
+	}
+	return map[string]interface{}{
+		"count":    len(results),
+		"next":     next,
+		"previous": previous,
+		"results":  results[start:end],
+	}, true
+}
+
+func (f *fakeAPI) create(collection string, body map[string]interface{}) (map[string]interface{}, error) {
+	item := make(map[string]interface{}, len(body)+2)
+	for k, v := range body {
+		item[k] = v
+	}
+	if _, ok := item["unique_id"]; !ok {
+		item["unique_id"] = uuid.NewString()
+	}
+	item["creation_date"] = time.Now().UTC().Format(time.RFC3339)
+
+	id := item["unique_id"]
+	if c, ok := fakeCollections[path.Base(collection)]; ok {
+		if c.create != nil {
+			c.create(f, item)
+		}
+		if c.normalize != nil {
+			c.normalize(item)
+		}
+		if c.id != nil {
+			id = c.id(item)
+		}
+	}
+
+	itemPath := fmt.Sprintf("%s/%v", collection, id)
+	if _, ok := f.items[itemPath]; ok {
+		return nil, fmt.Errorf("%v already exists", id)
+	}
+	f.collections[collection] = true
+	f.store(itemPath, item)
+	if err := f.replaceNested(itemPath, body); err != nil {
+		f.delete(itemPath)
+		return nil, err
+	}
+	return f.withNested(itemPath, item), nil
+}
+
+// replaceNested replaces the items of the nested collections of the item at
+// itemPath with those of the fields of body that set them.
+func (f *fakeAPI) replaceNested(itemPath string, body map[string]interface{}) error {
+	for _, field := range fakeCollections[path.Base(path.Dir(itemPath))].nested {
+		v, ok := body[field]
+		if !ok {
+			continue
+		}
+		elems, ok := v.([]interface{})
+		if !ok && v != nil {
+			return fmt.Errorf("%s must be a list", field)
+		}
+		collection := itemPath + "/" + field
+		var old []string
+		for _, p := range f.order {
+			if path.Dir(p) == collection {
+				old = append(old, p)
+			}
+		}
+		for _, p := range old {
+			f.delete(p)
+		}
+		for _, elem := range elems {
+			nestedItem, ok := elem.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%s must be a list of objects", field)
+			}
+			if _, err := f.create(collection, nestedItem); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// withNested sets the nested fields of the item at itemPath to the items of
+// their collections, and returns it.
+func (f *fakeAPI) withNested(itemPath string, item map[string]interface{}) map[string]interface{} {
+	for _, field := range fakeCollections[path.Base(path.Dir(itemPath))].nested {
+		item[field] = f.list(itemPath + "/" + field)
+	}
+	return item
+}
+
+func (f *fakeAPI) store(itemPath string, item map[string]interface{}) {
+	if _, ok := f.items[itemPath]; !ok {
+		f.order = append(f.order, itemPath)
+	}
+	f.items[itemPath] = item
+}
+
+func (f *fakeAPI) delete(itemPath string) {
+	order := f.order[:0]
+	for _, p := range f.order {
+		if p == itemPath || strings.HasPrefix(p, itemPath+"/") {
+			delete(f.items, p)
+			continue
+		}
+		order = append(order, p)
+	}
+	f.order = order
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// TestFakeAPIRoutes checks that the fake only serves the declared endpoints
+// below the prefixes of fakeRoutes.
+func TestFakeAPIRoutes(t *testing.T) {
+	api := newFakeAPI(t)
+	do := func(method, p string) int {
+		t.Helper()
+		req, err := http.NewRequest(method, api.URL+p, strings.NewReader(`{"title":"Disk full","note":"Rebooting","role":"a1b2c3d4-0000-4000-8000-000000000000"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Token "+api.token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	cases := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodPost, "/api/incidents/", http.StatusCreated},
+		{http.MethodGet, "/api/incidents/1/", http.StatusOK},
+		{http.MethodPost, "/api/incidents/1/note/", http.StatusCreated},
+		{http.MethodGet, "/api/incidents/1/note/", http.StatusOK},
+		{http.MethodPost, "/api/incidents/1/notes/", http.StatusNotFound},
+		{http.MethodPost, "/api/incidents/1/timeline/", http.StatusNotFound},
+		{http.MethodGet, "/api/incidents/1/note/not-a-note/", http.StatusNotFound},
+		{http.MethodPost, "/api/incidents/1/roles/", http.StatusCreated},
+		{http.MethodGet, "/api/incidents/1/roles/", http.StatusOK},
+		{http.MethodPost, "/api/incidents/1/role/", http.StatusNotFound},
+		{http.MethodPost, "/api/incidents/1/roles/assign/", http.StatusNotFound},
+		{http.MethodPost, "/api/account/teams/", http.StatusCreated},
+	}
+	for _, c := range cases {
+		if got := do(c.method, c.path); got != c.want {
+			t.Errorf("%s %s = %d, want %d", c.method, c.path, got, c.want)
+		}
+	}
+}
@@ -1,36 +1,31 @@
 package zenduty
 
 import (
-	"context"
 	"log"
-	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-// wrapReadWith404 wraps any ReadContextFunc to handle 404s globally
-func wrapReadWith404(readFunc schema.ReadContextFunc) schema.ReadContextFunc {
-	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-		diags := readFunc(ctx, d, m)
-
-		for _, diagItem := range diags {
-			if isNotFound(diagItem) {
-				log.Printf("[INFO] Removing resource %s because it's gone", d.Id())
-				d.SetId("")
-				return nil
-			}
-		}
-		return diags
+// handleReadError turns an error returned while reading a resource into
+// diagnostics. A 404 means the resource was deleted outside of Terraform, so
+// it is removed from state and planned for creation again.
+func handleReadError(d *schema.ResourceData, err error) diag.Diagnostics {
+	if isNotFoundError(err) {
+		log.Printf("[INFO] Removing resource %s because it's gone", d.Id())
+		d.SetId("")
+		return nil
 	}
+	return apiErrorDiags(err)
 }
 
-// isNotFound detects a 404 from the error or message
-func isNotFound(diagItem diag.Diagnostic) bool {
-	if diagItem.Severity != diag.Error {
-		return false
+// handleDeleteError turns an error returned while deleting a resource into
+// diagnostics. A 404 means the resource is already gone, which is the outcome
+// the delete was after.
+func handleDeleteError(d *schema.ResourceData, err error) diag.Diagnostics {
+	if isNotFoundError(err) {
+		log.Printf("[INFO] Resource %s was already deleted", d.Id())
+		return nil
 	}
-
-	re := regexp.MustCompile("404 Not Found")
-	return re.MatchString(diagItem.Summary) || re.MatchString(diagItem.Detail)
+	return apiErrorDiags(err)
 }
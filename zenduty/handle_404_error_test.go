@@ -0,0 +1,71 @@
+package zenduty
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/Zenduty/zenduty-go-sdk/client"
+)
+
+func TestIsNotFoundError(t *testing.T) {
+	notFound := &APIError{StatusCode: 404, Status: "404 Not Found", Method: "GET", Path: "/api/account/teams/x/"}
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{notFound, true},
+		{fmt.Errorf("reading team: %w", notFound), true},
+		{&APIError{StatusCode: 400, Status: "400 Bad Request", Message: "404 Not Found"}, false},
+		{&url.Error{Op: "Get", URL: "https://www.zenduty.com/api/account/teams/x/", Err: notFound}, true},
+		{errors.New(notFound.Error()), false},
+		{errors.New("connection refused"), false},
+	}
+	for _, c := range cases {
+		if got := isNotFoundError(c.err); got != c.want {
+			t.Errorf("isNotFoundError(%v) = %t, want %t", c.err, got, c.want)
+		}
+	}
+}
+
+// TestHandleReadError_deletedOutsideTerraform deletes a team through the SDK
+// and checks that the 404 the SDK returns for it removes the resource from
+// state on read and is ignored on delete.
+func TestHandleReadError_deletedOutsideTerraform(t *testing.T) {
+	api := newFakeAPI(t)
+	meta, err := (&Config{Token: api.token, BaseURL: api.URL}).Meta()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	apiclient, err := meta.Client(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, err := apiclient.Teams.CreateTeam(&client.CreateTeams{Name: testAccName()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := apiclient.Teams.DeleteTeam(team.UniqueID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := apiclient.Teams.GetTeamByID(team.UniqueID); !isNotFoundError(err) {
+		t.Fatalf("reading a deleted team: got %v, want a 404", err)
+	}
+
+	r := resourceTeam()
+	d := r.Data(nil)
+	d.SetId(team.UniqueID)
+	if diags := r.DeleteContext(ctx, d, meta); diags.HasError() {
+		t.Errorf("deleting a deleted team: %v", diags)
+	}
+	if diags := r.ReadContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("reading a deleted team: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("reading a deleted team kept it in state with ID %s", d.Id())
+	}
+}
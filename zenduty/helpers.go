@@ -1,11 +1,15 @@
 package zenduty
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/mail"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/go-cty/cty"
@@ -27,10 +31,6 @@ func checkList(a string, list []string) bool {
 	return false
 }
 
-func validateDate(date string) bool {
-
-	return true
-}
 func IsValidUUID(uuid string) bool {
 	r := regexp.MustCompile("^[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-4[a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12}$")
 	return r.MatchString(uuid)
@@ -135,3 +135,66 @@ func normalizeJSON(jsonString string) (string, error) {
 
 	return string(normalizedBytes), nil
 }
+
+// attributeDiag returns an error diagnostic attached to the attribute at
+// path, so Terraform points at the offending element of the configuration,
+// for example rules[2].delay.
+func attributeDiag(path cty.Path, summary string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       summary,
+		AttributePath: path,
+	}}
+}
+
+func attributeDiagf(path cty.Path, format string, a ...interface{}) diag.Diagnostics {
+	return attributeDiag(path, fmt.Sprintf(format, a...))
+}
+
+// resourceGetter is implemented by both *schema.ResourceData and
+// *schema.ResourceDiff, so a validation can run from CustomizeDiff at plan
+// time and again from the CRUD functions at apply time.
+type resourceGetter interface {
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+}
+
+// valueKnown reports whether the value at key is known. Values that depend on
+// other resources are unknown at plan time; they are validated at apply time.
+func valueKnown(d resourceGetter, key string) bool {
+	if diff, ok := d.(*schema.ResourceDiff); ok {
+		return diff.NewValueKnown(key)
+	}
+	return true
+}
+
+// validationDiags converts an error returned by a validation into
+// diagnostics, keeping the attribute path of a cty.PathError.
+func validationDiags(err error) diag.Diagnostics {
+	var pathErr cty.PathError
+	if errors.As(err, &pathErr) {
+		return attributeDiag(pathErr.Path, pathErr.Error())
+	}
+	return diag.FromErr(err)
+}
+
+// defaultResourceTimeout is the default of every operation in a resource's
+// timeouts block. The SDK cancels the context of an operation when it runs
+// out, which aborts the request in flight and any pending retries.
+const defaultResourceTimeout = 5 * time.Minute
+
+func resourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultResourceTimeout),
+		Read:   schema.DefaultTimeout(defaultResourceTimeout),
+		Update: schema.DefaultTimeout(defaultResourceTimeout),
+		Delete: schema.DefaultTimeout(defaultResourceTimeout),
+	}
+}
+
+// dataSourceID derives the ID of a data source from the arguments it was read
+// with, so that reading it again with the same arguments keeps the ID stable.
+func dataSourceID(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,123 @@
+package zenduty
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+)
+
+const redacted = "<REDACTED>"
+
+// sensitiveFields are masked wherever they appear in a logged JSON body.
+var sensitiveFields = []string{"integration_key", "webhook_url", "token"}
+
+// loggingTransport writes every request and response to the provider log
+// when TF_LOG is DEBUG or TRACE. The API token and the secrets listed in
+// sensitiveFields are masked before anything is written.
+type loggingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !logging.IsDebugOrHigher() {
+		return t.base.RoundTrip(req)
+	}
+
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = body
+		req = req.Clone(req.Context())
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	log.Printf("[DEBUG] zenduty API request: %s %s\n%s%s", req.Method, req.URL, redactHeaders(req.Header), redactBody(reqBody))
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	latency := time.Since(start)
+	if err != nil {
+		log.Printf("[DEBUG] zenduty API request failed: %s %s (%s): %s", req.Method, req.URL, latency, err)
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+	log.Printf("[DEBUG] zenduty API response: %s %s: %s (%s)\n%s", req.Method, req.URL, resp.Status, latency, redactBody(respBody))
+
+	return resp, nil
+}
+
+func redactHeaders(header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if strings.EqualFold(name, "Authorization") {
+			value = redacted
+		}
+		b.WriteString(name + ": " + value + "\n")
+	}
+	return b.String()
+}
+
+// redactBody masks sensitive fields of a JSON body. Bodies that are not JSON
+// are logged as they are, since the API only sends secrets in JSON.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return string(body)
+	}
+	// Encode without HTML escaping so the log reads <REDACTED>, not \u003c.
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(redactValue(data)); err != nil {
+		return string(body)
+	}
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if checkList(key, sensitiveFields) {
+				if s, ok := item.(string); ok && s == "" {
+					continue
+				}
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
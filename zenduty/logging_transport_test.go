@@ -0,0 +1,75 @@
+package zenduty
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testLoggingResponse = `{"name":"Webhook","integration_key":"secret-integration-key","webhook_url":"https://hooks.invalid/secret-webhook-url","integrations":[{"config":{"token":"secret-nested-token"}}],"summary":""}`
+
+// captureLog sends a request with secrets through a loggingTransport and
+// returns what was logged and the response body handed back to the caller.
+func captureLog(t *testing.T) (string, string) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testLoggingResponse))
+	}))
+	defer server.Close()
+
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(ioutil.Discard)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/account/teams/", strings.NewReader(`{"name":"Webhook","token":"secret-request-token"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Token secret-api-token")
+	resp, err := (&http.Client{Transport: &loggingTransport{base: http.DefaultTransport}}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return logged.String(), string(body)
+}
+
+func TestLoggingTransport_redactsSecrets(t *testing.T) {
+	t.Setenv("TF_LOG", "DEBUG")
+	logged, body := captureLog(t)
+
+	if body != testLoggingResponse {
+		t.Errorf("response body = %s, want it passed through unchanged", body)
+	}
+	for _, secret := range []string{"secret-api-token", "secret-request-token", "secret-integration-key", "secret-webhook-url", "secret-nested-token"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("log contains %q:\n%s", secret, logged)
+		}
+	}
+	for _, want := range []string{"Authorization: " + redacted, `"integration_key": "` + redacted, `"webhook_url": "` + redacted, `"token": "` + redacted, `"summary": ""`, `"name": "Webhook"`} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("log does not contain %q:\n%s", want, logged)
+		}
+	}
+}
+
+func TestLoggingTransport_quietWithoutDebug(t *testing.T) {
+	t.Setenv("TF_LOG", "")
+	logged, body := captureLog(t)
+
+	if logged != "" {
+		t.Errorf("logged without TF_LOG=DEBUG:\n%s", logged)
+	}
+	if body != testLoggingResponse {
+		t.Errorf("response body = %s, want it passed through unchanged", body)
+	}
+}
@@ -2,9 +2,11 @@ package zenduty
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
@@ -20,34 +22,94 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeString,
 				Description: "The base url of the Zenduty",
 				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ZENDUTY_BASE_URL", nil),
+			},
+			"max_retries": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of times a request is retried after a rate limit or server error",
+				Optional:     true,
+				Default:      defaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_wait": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of seconds to wait between two retries",
+				Optional:     true,
+				Default:      int(defaultRetryMaxWait / time.Second),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"requests_per_second": &schema.Schema{
+				Type:         schema.TypeFloat,
+				Description:  "Maximum number of requests per second sent to the Zenduty API, shared by all concurrent operations. Set to 0 to disable the limit",
+				Optional:     true,
+				Default:      defaultRequestsPerSecond,
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"request_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "Number of seconds a single request may take before it is aborted",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ZENDUTY_REQUEST_TIMEOUT", int(defaultRequestTimeout/time.Second)),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"proxy_url": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "URL of the proxy used to reach the Zenduty API. Defaults to the HTTPS_PROXY and NO_PROXY environment variables",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ZENDUTY_PROXY_URL", nil),
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+			},
+			"ca_cert_file": &schema.Schema{
+				Type:          schema.TypeString,
+				Description:   "Path to a PEM encoded CA bundle trusted in addition to the system roots",
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("ZENDUTY_CA_CERT_FILE", nil),
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+			"ca_cert_pem": &schema.Schema{
+				Type:          schema.TypeString,
+				Description:   "PEM encoded CA bundle trusted in addition to the system roots",
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("ZENDUTY_CA_CERT_PEM", nil),
+				ConflictsWith: []string{"ca_cert_file"},
+			},
+			"insecure_skip_verify": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "Skip verification of the API server certificate. Only use this for testing",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ZENDUTY_INSECURE_SKIP_VERIFY", false),
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"zenduty_teams":               resourceTeam(),
-			"zenduty_roles":               resourceRoles(),
-			"zenduty_services":            resourceServices(),
-			"zenduty_integrations":        resourceIntegrations(),
-			"zenduty_schedules":           resourceSchedules(),
-			"zenduty_esp":                 resourceEsp(),
-			"zenduty_incidents":           resourceIncidents(),
-			"zenduty_invite":              resourceInvite(),
-			"zenduty_member":              resourceMembers(),
-			"zenduty_alertrules":          resourceAlertRules(),
-			"zenduty_tags":                resourceTags(),
-			"zenduty_priorities":          resourcePriority(),
-			"zenduty_maintenance_window":  resourceMaintenanceWindow(),
-			"zenduty_notification_rules":  resourceNotificationRules(),
-			"zenduty_user":                resourceUser(),
-			"zenduty_account_role":        resourceAccountRole(),
-			"zenduty_assign_account_role": resourceAssignAccountRole(),
-			"zenduty_globalrouter":        resourceGlobalRouter(),
-			"zenduty_globalrouting_rule":  resourceGlobalRoutingRules(),
-			"zenduty_sla":                 resourceSLA(),
-			"zenduty_post_incident_tasks": resourcePostIncidentTasks(),
-			"zenduty_task_templates":      resourceTaskTemplates(),
-			"zenduty_task_template_tasks": resourceTaskTemplateTaskTasks(),
-			"zenduty_team_permissions":    resourceTeamLevelPermissions(),
-			"zenduty_outgoing_rules":      resourceOutgoingRules(),
+			"zenduty_teams":                    resourceTeam(),
+			"zenduty_roles":                    resourceRoles(),
+			"zenduty_services":                 resourceServices(),
+			"zenduty_integrations":             resourceIntegrations(),
+			"zenduty_schedules":                resourceSchedules(),
+			"zenduty_esp":                      resourceEsp(),
+			"zenduty_incidents":                resourceIncidents(),
+			"zenduty_invite":                   resourceInvite(),
+			"zenduty_member":                   resourceMembers(),
+			"zenduty_alertrules":               resourceAlertRules(),
+			"zenduty_tags":                     resourceTags(),
+			"zenduty_priorities":               resourcePriority(),
+			"zenduty_maintenance_window":       resourceMaintenanceWindow(),
+			"zenduty_notification_rules":       resourceNotificationRules(),
+			"zenduty_user":                     resourceUser(),
+			"zenduty_account_role":             resourceAccountRole(),
+			"zenduty_assign_account_role":      resourceAssignAccountRole(),
+			"zenduty_globalrouter":             resourceGlobalRouter(),
+			"zenduty_globalrouting_rule":       resourceGlobalRoutingRules(),
+			"zenduty_sla":                      resourceSLA(),
+			"zenduty_post_incident_tasks":      resourcePostIncidentTasks(),
+			"zenduty_task_templates":           resourceTaskTemplates(),
+			"zenduty_task_template_tasks":      resourceTaskTemplateTaskTasks(),
+			"zenduty_team_permissions":         resourceTeamLevelPermissions(),
+			"zenduty_outgoing_rules":           resourceOutgoingRules(),
+			"zenduty_incident_note":            resourceIncidentNote(),
+			"zenduty_incident_role_assignment": resourceIncidentRoleAssignment(),
+			"zenduty_schedule_override":        resourceScheduleOverride(),
+			"zenduty_follow_the_sun_schedule":  resourceFollowTheSunSchedule(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -57,6 +119,9 @@ func Provider() *schema.Provider {
 			"zenduty_services":             dataSourceServices(),
 			"zenduty_integrations":         dataSourceIntegrations(),
 			"zenduty_schedules":            dataSourceSchedules(),
+			"zenduty_oncall":               dataSourceOncall(),
+			"zenduty_schedule_preview":     dataSourceSchedulePreview(),
+			"zenduty_schedule_ical":        dataSourceScheduleICal(),
 			"zenduty_esp":                  dataSourceEsp(),
 			"zenduty_user":                 dataSourceUsers(),
 			"zenduty_alertrules":           dataSourceAlertRules(),
@@ -76,18 +141,38 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	token := d.Get("token").(string)
 	baseURL := d.Get("base_url").(string)
 	var diags diag.Diagnostics
-	if token != "" {
-		client := Config{
-			Token:   token,
-			BaseURL: baseURL,
-		}
-		return &client, diags
+	if token == "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create zenduty client provider",
+			Detail:   "Unable to auth user for authenticated zenduty client",
+		})
+		return nil, diags
+	}
+
+	config := Config{
+		Token:        token,
+		BaseURL:      baseURL,
+		MaxRetries:   d.Get("max_retries").(int),
+		RetryMaxWait: time.Duration(d.Get("retry_max_wait").(int)) * time.Second,
+
+		RequestsPerSecond: d.Get("requests_per_second").(float64),
+
+		RequestTimeout:     time.Duration(d.Get("request_timeout").(int)) * time.Second,
+		ProxyURL:           d.Get("proxy_url").(string),
+		CACertFile:         d.Get("ca_cert_file").(string),
+		CACertPEM:          d.Get("ca_cert_pem").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+	}
+	meta, err := config.Meta()
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create zenduty client provider",
+			Detail:   err.Error(),
+		})
+		return nil, diags
 	}
-	diags = append(diags, diag.Diagnostic{
-		Severity: diag.Error,
-		Summary:  "Unable to create zenduty client provider",
-		Detail:   "Unable to auth user for authenticated zenduty client",
-	})
 
-	return nil, diags
+	return meta, diags
 }
@@ -1,13 +1,174 @@
 package zenduty
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testAccNamePrefix starts the name of every object created by acceptance
+// tests.
+const testAccNamePrefix = "tf-acc-test"
 
 var testAccProviders map[string]*schema.Provider
 var testAccProvider *schema.Provider
 
+// testAccProviderFactories are used by resource.Test. Each factory returns a
+// new provider, so a configured provider never leaks between test steps.
+var testAccProviderFactories = map[string]func() (*schema.Provider, error){
+	"zenduty": func() (*schema.Provider, error) {
+		return Provider(), nil
+	},
+}
+
 func init() {
 	testAccProvider = Provider()
 	testAccProviders = map[string]*schema.Provider{
 		"example": testAccProvider,
 	}
 }
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testAccPreCheck runs acceptance tests against the account of
+// ZENDUTY_API_KEY when it is set, and against a fake API otherwise.
+func testAccPreCheck(t *testing.T) {
+	if os.Getenv("ZENDUTY_API_KEY") != "" {
+		return
+	}
+	api := newFakeAPI(t)
+	t.Setenv("ZENDUTY_API_KEY", api.token)
+	t.Setenv("ZENDUTY_BASE_URL", api.URL)
+}
+
+// testAccName returns a unique name for an object created by a test.
+func testAccName() string {
+	return resource.PrefixedUniqueId(testAccNamePrefix + "-")
+}
+
+// testAccMeta returns a Meta configured from the same environment as the
+// provider under test, for checks that call the API directly.
+func testAccMeta() (*Meta, error) {
+	config := Config{
+		Token:   os.Getenv("ZENDUTY_API_KEY"),
+		BaseURL: os.Getenv("ZENDUTY_BASE_URL"),
+	}
+	return config.Meta()
+}
+
+// testAccUsername returns the username of an existing account member for
+// tests that reference one, taken from ZENDUTY_TEST_USERNAME.
+func testAccUsername(t *testing.T) string {
+	return testAccExisting(t, "ZENDUTY_TEST_USERNAME", fakeUsername())
+}
+
+// testAccExisting returns the ID of an object the provider cannot create,
+// taken from envVar. The fake API accepts any ID, so fake is used when
+// running against it; against a real account the test is skipped when envVar
+// is not set.
+func testAccExisting(t *testing.T, envVar, fake string) string {
+	if v := os.Getenv(envVar); v != "" {
+		return v
+	}
+	if os.Getenv("ZENDUTY_API_KEY") != "" {
+		t.Skipf("%s must be set for this test", envVar)
+	}
+	return fake
+}
+
+// testAccCheckResourceID records the ID of resourceName in id on its first
+// call and fails if it has changed on later calls, so a test can check that
+// an update was made in place rather than by replacement.
+func testAccCheckResourceID(resourceName string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+		if *id == "" {
+			*id = rs.Primary.ID
+		} else if rs.Primary.ID != *id {
+			return fmt.Errorf("%s was replaced: ID changed from %s to %s", resourceName, *id, rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+// testAccCheckDestroy checks that no resource of resourceType left in the
+// state can still be read. It runs the resource's own read, which clears the
+// ID once the API reports the object as not found.
+func testAccCheckDestroy(resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		meta, err := testAccMeta()
+		if err != nil {
+			return err
+		}
+		r := Provider().ResourcesMap[resourceType]
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			d := r.Data(rs.Primary)
+			if diags := r.ReadContext(context.Background(), d, meta); diags.HasError() {
+				return fmt.Errorf("reading %s %s: %v", resourceType, rs.Primary.ID, diags)
+			}
+			if d.Id() != "" {
+				return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+// testAccImportStateIDFunc returns the composite ID the importer of
+// resourceName expects: the given attributes followed by the resource ID,
+// joined with slashes.
+func testAccImportStateIDFunc(resourceName string, attrs ...string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("%s not found in state", resourceName)
+		}
+		parts := make([]string, 0, len(attrs)+1)
+		for _, attr := range attrs {
+			parts = append(parts, rs.Primary.Attributes[attr])
+		}
+		return strings.Join(append(parts, rs.Primary.ID), "/"), nil
+	}
+}
+
+// testCheckPathError checks that err is a cty.PathError about the attribute
+// at wantPath, written the way formatPath writes it, whose message contains
+// wantMessage. An empty wantPath expects no error.
+func testCheckPathError(t *testing.T, err error, wantPath, wantMessage string) {
+	t.Helper()
+	if wantPath == "" {
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+		return
+	}
+	var pathErr cty.PathError
+	if !errors.As(err, &pathErr) {
+		t.Errorf("error = %v, want an error about %s", err, wantPath)
+		return
+	}
+	if got := formatPath(pathErr.Path); got != wantPath {
+		t.Errorf("error is about %s, want %s: %s", got, wantPath, err)
+	}
+	if !strings.Contains(err.Error(), wantMessage) {
+		t.Errorf("error = %q, want it to contain %q", err, wantMessage)
+	}
+}
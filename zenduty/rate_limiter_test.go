@@ -0,0 +1,136 @@
+package zenduty
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_burst(t *testing.T) {
+	cases := []struct {
+		rate      float64
+		wantBurst float64
+	}{
+		{10, 10},
+		{2.5, 3},
+		{0.5, 1},
+	}
+	for _, c := range cases {
+		if l := newRateLimiter(c.rate); l.burst != c.wantBurst || l.tokens != c.wantBurst {
+			t.Errorf("newRateLimiter(%g): burst %g with %g tokens, want a full bucket of %g", c.rate, l.burst, l.tokens, c.wantBurst)
+		}
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	l := newRateLimiter(20)
+	ctx := context.Background()
+
+	for i := 0; i < 20; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if l.tokens > 0.5 {
+		t.Errorf("%g tokens left after the burst, want 0", l.tokens)
+	}
+
+	// The bucket is empty: the next token comes after 1/20 s.
+	start := time.Now()
+	if err := l.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("waited %s with an empty bucket, want about 50ms", elapsed)
+	}
+}
+
+func TestRateLimiter_refill(t *testing.T) {
+	l := newRateLimiter(10)
+	l.tokens = 0
+	// Half a second at 10 requests per second refills 5 tokens, and
+	// a minute refills no more than the burst.
+	l.last = time.Now().Add(-500 * time.Millisecond)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if l.tokens < 3.9 || l.tokens > 4.1 {
+		t.Errorf("%g tokens left, want 4", l.tokens)
+	}
+
+	l.last = time.Now().Add(-time.Minute)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if l.tokens < 8.9 || l.tokens > 9.1 {
+		t.Errorf("%g tokens left, want 9", l.tokens)
+	}
+}
+
+// TestRateLimiter_concurrent checks that concurrent callers share the rate:
+// 10 requests at 20 per second with a burst of 2 take 8/20 s.
+func TestRateLimiter_concurrent(t *testing.T) {
+	l := newRateLimiter(20)
+	l.burst, l.tokens = 2, 2
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.Wait(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if elapsed := time.Since(start); elapsed < 350*time.Millisecond {
+		t.Errorf("10 requests took %s, want at least 400ms", elapsed)
+	}
+}
+
+func TestRateLimiter_canceled(t *testing.T) {
+	l := newRateLimiter(1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	// The token reserved by the canceled call is given back.
+	if l.tokens < -0.1 || l.tokens > 0.1 {
+		t.Errorf("%g tokens left, want 0", l.tokens)
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+	transport := &rateLimitTransport{base: http.DefaultTransport, limiter: newRateLimiter(1)}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	// The bucket is empty, and the request gives up before a token comes.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := transport.RoundTrip(req.WithContext(ctx)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if requests != 1 {
+		t.Errorf("the server got %d requests, want 1", requests)
+	}
+}
@@ -2,11 +2,10 @@ package zenduty
 
 import (
 	"context"
-	"errors"
-	"fmt"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,9 +14,10 @@ import (
 func resourceAccountRole() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceCreateAccountRole,
-		ReadContext:   wrapReadWith404(resourceReadAccountRole),
+		ReadContext:   resourceReadAccountRole,
 		UpdateContext: resourceUpdateAccountRole,
 		DeleteContext: resourceDeleteAccountRole,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -83,12 +83,13 @@ func validateAccountRoles(Ctx context.Context, d *schema.ResourceData, m interfa
 
 	newRole.Name = name
 	newRole.Description = description
-	for _, permission := range permissions {
+	for i, permission := range permissions {
+		path := cty.GetAttrPath("permissions").IndexInt(i)
 		if permission.(string) == "" {
-			return nil, diag.FromErr(errors.New("permission must not be empty"))
+			return nil, attributeDiag(path, "permission must not be empty")
 		}
 		if !checkList(permission.(string), permissionsList) {
-			return nil, diag.FromErr(fmt.Errorf("invalid permission received %s", permission.(string)))
+			return nil, attributeDiagf(path, "invalid permission received %s", permission.(string))
 		}
 		newRole.Permissions = append(newRole.Permissions, permission.(string))
 	}
@@ -97,49 +98,61 @@ func validateAccountRoles(Ctx context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceCreateAccountRole(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	newrole, validationerr := validateAccountRoles(ctx, d, m)
 	if validationerr != nil {
 		return validationerr
 	}
 	role, err := apiclient.AccountRole.CreateAccountRole(newrole)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(role.UniqueID)
 	return nil
 }
 
 func resourceUpdateAccountRole(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	newrole, validationerr := validateAccountRoles(ctx, d, m)
 	if validationerr != nil {
 		return validationerr
 	}
 	role, err := apiclient.AccountRole.UpdateAccountRole(d.Id(), newrole)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(role.UniqueID)
 	return nil
 }
 
 func resourceDeleteAccountRole(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
-
-	err := apiclient.AccountRole.DeleteAccountRole(d.Id())
+	apiclient, err := m.(*Meta).Client(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	err = apiclient.AccountRole.DeleteAccountRole(d.Id())
+	if err != nil {
+		return handleDeleteError(d, err)
+	}
 	return nil
 }
 
 func resourceReadAccountRole(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
-	role, err := apiclient.AccountRole.GetAccountRoleByID(d.Id())
+	apiclient, err := m.(*Meta).Client(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	role, err := apiclient.AccountRole.GetAccountRoleByID(d.Id())
+	if err != nil {
+		return handleReadError(d, err)
+	}
 	d.SetId(role.UniqueID)
 	d.Set("name", role.Name)
 	d.Set("description", role.Description)
@@ -0,0 +1,53 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAccountRole_basic(t *testing.T) {
+	name := testAccName()
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_account_role"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountRoleConfig(name, `"incident_read"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_account_role.test", &id),
+					resource.TestCheckResourceAttr("zenduty_account_role.test", "name", name),
+					resource.TestCheckResourceAttr("zenduty_account_role.test", "permissions.#", "1"),
+				),
+			},
+			{
+				Config: testAccAccountRoleConfig(name, `"incident_read", "schedule_read"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_account_role.test", &id),
+					resource.TestCheckResourceAttr("zenduty_account_role.test", "permissions.#", "2"),
+					resource.TestCheckResourceAttr("zenduty_account_role.test", "permissions.1", "schedule_read"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_account_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccAccountRoleConfig declares an account role granting permissions, a
+// comma separated list of quoted permission names.
+func testAccAccountRoleConfig(name, permissions string) string {
+	return fmt.Sprintf(`
+resource "zenduty_account_role" "test" {
+  name        = %q
+  description = "Created by acceptance tests"
+  permissions = [%s]
+}
+`, name, permissions)
+}
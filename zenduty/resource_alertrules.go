@@ -2,12 +2,12 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -17,10 +17,12 @@ func resourceAlertRules() *schema.Resource {
 		CreateContext: resourceCreateAlertRules,
 		UpdateContext: resourceUpdateAlertRules,
 		DeleteContext: resourceDeleteAlertRules,
-		ReadContext:   wrapReadWith404(resourceReadAlertRules),
+		ReadContext:   resourceReadAlertRules,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourceAlertRulesImporter,
 		},
+		CustomizeDiff: resourceAlertRulesCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"description": {
 				Type:     schema.TypeString,
@@ -69,7 +71,68 @@ func resourceAlertRules() *schema.Resource {
 		},
 	}
 }
+func resourceAlertRulesCustomizeDiff(Ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	return validateAlertRuleActions(d)
+}
+
+// validateAlertRuleActions checks the action_type of every action and the
+// value or key that action type expects.
+func validateAlertRuleActions(d resourceGetter) error {
+	actions := d.Get("actions").([]interface{})
+	for i, action := range actions {
+		ruleMap, ok := action.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		path := cty.GetAttrPath("actions").IndexInt(i)
+		if !valueKnown(d, fmt.Sprintf("actions.%d.action_type", i)) {
+			continue
+		}
+		actionType := ruleMap["action_type"].(int)
+		if (actionType > 18) || (actionType < 1) {
+			return path.GetAttr("action_type").NewErrorf("action_type is not valid")
+		}
+
+		if actionType == 11 && valueKnown(d, fmt.Sprintf("actions.%d.key", i)) {
+			key, _ := ruleMap["key"].(string)
+			if key == "" {
+				return path.GetAttr("key").NewErrorf("key(ie..role_id) is required")
+			}
+			if !IsValidUUID(key) {
+				return path.GetAttr("key").NewErrorf("key(ie..role_id) is not valid UUID")
+			}
+		}
+
+		if !valueKnown(d, fmt.Sprintf("actions.%d.value", i)) {
+			continue
+		}
+		value, _ := ruleMap["value"].(string)
+		if ((actionType != 3) && (actionType != 18)) && (value == "") {
+			return path.GetAttr("value").NewErrorf("value is required")
+		}
+		if ((actionType == 4) || (actionType == 14) || (actionType == 15) || (actionType == 16)) && (!IsValidUUID(value)) {
+			return path.GetAttr("value").NewErrorf("%s is not a valid UUID", value)
+		}
+		if (actionType == 7) && (!(value == "0" || value == "1")) {
+			return path.GetAttr("value").NewErrorf("incident urgency should be 0 or 1")
+		}
+		if actionType == 1 {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return path.GetAttr("value").NewErrorf("value is not valid")
+			}
+			if n < 0 || n > 5 {
+				return path.GetAttr("value").NewErrorf("value should be between 0 and 5")
+			}
+		}
+	}
+	return nil
+}
+
 func AlertRuleAction(Ctx context.Context, d *schema.ResourceData, m interface{}, newAlertRule *client.AlertRule) ([]client.AlertAction, diag.Diagnostics) {
+	if err := validateAlertRuleActions(d); err != nil {
+		return nil, validationDiags(err)
+	}
 	actions := d.Get("actions").([]interface{})
 	newAlertRule.Actions = make([]client.AlertAction, len(actions))
 	for i, action := range actions {
@@ -80,33 +143,11 @@ func AlertRuleAction(Ctx context.Context, d *schema.ResourceData, m interface{},
 		if v, ok := ruleMap["action_type"]; ok {
 			newAction.ActionType = v.(int)
 		}
-
-		if (newAction.ActionType > 18) || (newAction.ActionType < 1) {
-			return nil, diag.FromErr(errors.New("action_type is not valid"))
-		}
-
 		if v, ok := ruleMap["value"]; ok {
 			value = v.(string)
 		}
 
-		if ((newAction.ActionType != 3) && (newAction.ActionType != 18)) && (value == "") {
-			return nil, diag.FromErr(errors.New("value is required"))
-		}
-		if ((newAction.ActionType == 4) || (newAction.ActionType == 14) || (newAction.ActionType == 15) || (newAction.ActionType == 16)) && (!IsValidUUID(value)) {
-			return nil, diag.FromErr(errors.New(value + " is not a valid UUID"))
-		}
-
-		if (newAction.ActionType == 7) && (!(value == "0" || value == "1")) {
-			return nil, diag.FromErr(errors.New("incident urgency should be 0 or 1"))
-		}
 		if newAction.ActionType == 1 {
-			i, err := strconv.Atoi(value)
-			if i < 0 || i > 5 {
-				return nil, diag.FromErr(errors.New("value should be between 0 and 5"))
-			}
-			if err != nil {
-				return nil, diag.FromErr(errors.New("value is not valid"))
-			}
 			newAction.Value = value
 		} else if newAction.ActionType == 3 {
 			value = ""
@@ -121,32 +162,15 @@ func AlertRuleAction(Ctx context.Context, d *schema.ResourceData, m interface{},
 			if v, ok := ruleMap["key"]; ok {
 				key = v.(string)
 			}
-			if key == "" {
-				return nil, diag.FromErr(errors.New("key(ie..role_id) is required"))
-			}
-			if !IsValidUUID(key) {
-				return nil, diag.FromErr(errors.New("key(ie..role_id) is not valid UUID"))
-			}
 
 			newAction.Key = key
 
 		} else if newAction.ActionType == 14 {
 			newAction.SLA = value
 			value = ""
-			if newAction.SLA == "" {
-				return nil, diag.FromErr(errors.New("sla is required"))
-			} else if !IsValidUUID(newAction.SLA) {
-				return nil, diag.FromErr(errors.New("sla is not valid UUID"))
-			}
-
 		} else if newAction.ActionType == 15 {
 			newAction.TeamPriority = value
 			value = ""
-			if newAction.TeamPriority == "" {
-				return nil, diag.FromErr(errors.New("team_priority is required"))
-			} else if !IsValidUUID(newAction.TeamPriority) {
-				return nil, diag.FromErr(errors.New("team_priority is not valid UUID"))
-			}
 		} else if newAction.ActionType == 16 {
 			newAction.TaskTemplates = value
 			value = ""
@@ -175,13 +199,13 @@ func ValidateAncCreateAlertRules(Ctx context.Context, d *schema.ResourceData, m
 
 	}
 	if newAlertRule.Description == "" {
-		return nil, diag.FromErr(errors.New("description is required"))
+		return nil, attributeDiag(cty.GetAttrPath("description"), "description is required")
 	}
 	if newAlertRule.RuleJSON == "" {
-		return nil, diag.FromErr(errors.New("rule_json is required"))
+		return nil, attributeDiag(cty.GetAttrPath("rule_json"), "rule_json is required")
 	}
 	if !isJSONString(newAlertRule.RuleJSON) {
-		return nil, diag.FromErr(errors.New("rule_json is not valid JSON"))
+		return nil, attributeDiag(cty.GetAttrPath("rule_json"), "rule_json is not valid JSON")
 	}
 	actions, actionErr := AlertRuleAction(Ctx, d, m, newAlertRule)
 	if actionErr != nil {
@@ -194,7 +218,10 @@ func ValidateAncCreateAlertRules(Ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceCreateAlertRules(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	var diags diag.Diagnostics
 	var teamID, serviceID, integrationID string
 
@@ -210,14 +237,17 @@ func resourceCreateAlertRules(Ctx context.Context, d *schema.ResourceData, m int
 	alertRule, err := apiclient.AlertRules.CreateAlertRule(teamID, serviceID, integrationID, newRule)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(alertRule.UniqueID)
 	return diags
 }
 
 func resourceUpdateAlertRules(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	var diags diag.Diagnostics
 	var teamID, serviceID, integrationID string
 
@@ -230,16 +260,19 @@ func resourceUpdateAlertRules(Ctx context.Context, d *schema.ResourceData, m int
 		return ruleErr
 	}
 
-	_, err := apiclient.AlertRules.UpdateAlertRule(teamID, serviceID, integrationID, d.Id(), newRule)
+	_, err = apiclient.AlertRules.UpdateAlertRule(teamID, serviceID, integrationID, d.Id(), newRule)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	return diags
 }
 
 func resourceReadAlertRules(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	var diags diag.Diagnostics
 	var teamID, serviceID, integrationID string
 
@@ -249,7 +282,7 @@ func resourceReadAlertRules(Ctx context.Context, d *schema.ResourceData, m inter
 
 	rule, err := apiclient.AlertRules.GetAlertRule(teamID, serviceID, integrationID, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.SetId(rule.UniqueID)
 	// Normalize JSON before setting to avoid formatting issues
@@ -300,12 +333,15 @@ func flattenAlertActions(rule *client.AlertRule) []map[string]interface{} {
 
 func resourceDeleteAlertRules(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	teamID, serviceID, integrationID, uniqueID := d.Get("team_id").(string), d.Get("service_id").(string), d.Get("integration_id").(string), d.Id()
 
-	err := apiclient.AlertRules.DeleteAlertRule(teamID, serviceID, integrationID, uniqueID)
+	err = apiclient.AlertRules.DeleteAlertRule(teamID, serviceID, integrationID, uniqueID)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return diags
 }
@@ -0,0 +1,108 @@
+package zenduty
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccAlertRules_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	application := testAccApplication(t)
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_alertrules"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAlertRulesConfig(name, username, application, "critical"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_alertrules.test", &id),
+					resource.TestCheckResourceAttr("zenduty_alertrules.test", "actions.#", "2"),
+					resource.TestCheckResourceAttrPair("zenduty_alertrules.test", "actions.1.value", "zenduty_esp.test", "id"),
+				),
+			},
+			{
+				Config: testAccAlertRulesConfig(name, username, application, "error"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_alertrules.test", &id),
+					resource.TestCheckResourceAttr("zenduty_alertrules.test", "description", "Route error alerts"),
+					resource.TestMatchResourceAttr("zenduty_alertrules.test", "rule_json", regexp.MustCompile(`"value":"error"`)),
+					resource.TestCheckResourceAttr("zenduty_alertrules.test", "actions.0.action_type", "1"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_alertrules.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_alertrules.test", "team_id", "service_id", "integration_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAlertRulesConfig(name, username, application, severity string) string {
+	return testAccIntegrationsConfig(name, username, application, 1) + fmt.Sprintf(`
+resource "zenduty_alertrules" "test" {
+  description    = "Route %[1]s alerts"
+  team_id        = zenduty_teams.test.id
+  service_id     = zenduty_services.test.id
+  integration_id = zenduty_integrations.test.id
+  rule_json = jsonencode({
+    condition = "AND"
+    conditions = [{
+      key      = "payload.severity"
+      operator = "is"
+      value    = %[1]q
+    }]
+  })
+
+  actions {
+    action_type = 1
+    value       = "0"
+  }
+
+  actions {
+    action_type = 4
+    value       = zenduty_esp.test.id
+  }
+}
+`, severity)
+}
+
+func TestValidateAlertRuleActions(t *testing.T) {
+	const id = "3c3e8a52-9e2a-4c7d-a1e5-0a6f3a9b7c11"
+	action := func(actionType int, key, value string) interface{} {
+		return map[string]interface{}{"action_type": actionType, "key": key, "value": value}
+	}
+	cases := []struct {
+		name        string
+		actions     []interface{}
+		wantPath    string
+		wantMessage string
+	}{
+		{"no actions", nil, "", ""},
+		{"valid", []interface{}{action(1, "", "3"), action(3, "", ""), action(4, "", id), action(7, "", "0"), action(11, id, "x"), action(18, "", "")}, "", ""},
+		{"action type too low", []interface{}{action(0, "", "x")}, "actions[0].action_type", "action_type is not valid"},
+		{"action type too high", []interface{}{action(19, "", "x")}, "actions[0].action_type", "action_type is not valid"},
+		{"role without key", []interface{}{action(11, "", "x")}, "actions[0].key", "key(ie..role_id) is required"},
+		{"role with invalid key", []interface{}{action(11, "admin", "x")}, "actions[0].key", "key(ie..role_id) is not valid UUID"},
+		{"missing value", []interface{}{action(2, "", "")}, "actions[0].value", "value is required"},
+		{"invalid UUID value", []interface{}{action(4, "", "x")}, "actions[0].value", "x is not a valid UUID"},
+		{"invalid urgency", []interface{}{action(7, "", "2")}, "actions[0].value", "incident urgency should be 0 or 1"},
+		{"non-numeric priority", []interface{}{action(1, "", "high")}, "actions[0].value", "value is not valid"},
+		{"priority out of range", []interface{}{action(1, "", "6")}, "actions[0].value", "value should be between 0 and 5"},
+		{"second action", []interface{}{action(3, "", ""), action(14, "", "x")}, "actions[1].value", "x is not a valid UUID"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAlertRules().Schema, map[string]interface{}{"actions": c.actions})
+			testCheckPathError(t, validateAlertRuleActions(d), c.wantPath, c.wantMessage)
+		})
+	}
+}
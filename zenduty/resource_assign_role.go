@@ -2,11 +2,11 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 	"regexp"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,9 +16,10 @@ import (
 func resourceAssignAccountRole() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceAssignRole,
-		ReadContext:   wrapReadWith404(resourceReadRole),
+		ReadContext:   resourceReadRole,
 		UpdateContext: resourceUpdateAssignRole,
 		DeleteContext: resourceRemoveAssignedRole,
+		Timeouts:      resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"account_role": {
 				Type:             schema.TypeString,
@@ -35,7 +36,10 @@ func resourceAssignAccountRole() *schema.Resource {
 }
 
 func resourceAssignRole(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	role := &client.AddRoleToUser{}
 
@@ -47,14 +51,17 @@ func resourceAssignRole(ctx context.Context, d *schema.ResourceData, m interface
 
 	userrole, err := apiclient.AccountRole.AssignRoleToUser(username, role)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(userrole.Username)
 	return nil
 }
 
 func resourceUpdateAssignRole(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	role := &client.AddRoleToUser{}
 
@@ -66,27 +73,30 @@ func resourceUpdateAssignRole(Ctx context.Context, d *schema.ResourceData, m int
 	}
 	username := d.Get("username").(string)
 	if username != d.Id() {
-		return diag.FromErr(errors.New("cannot update username"))
+		return attributeDiag(cty.GetAttrPath("username"), "cannot update username")
 	}
 
-	_, err := apiclient.AccountRole.AssignRoleToUser(username, role)
+	_, err = apiclient.AccountRole.AssignRoleToUser(username, role)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	return diags
 
 }
 
 func resourceRemoveAssignedRole(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	role := &client.AddRoleToUser{AccountRole: nil}
 
 	var diags diag.Diagnostics
 	username := d.Get("username").(string)
-	_, err := apiclient.AccountRole.AssignRoleToUser(username, role)
+	_, err = apiclient.AccountRole.AssignRoleToUser(username, role)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return diags
 }
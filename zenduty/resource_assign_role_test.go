@@ -0,0 +1,60 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// zenduty_assign_account_role cannot be read back or imported, so the test
+// only checks that the assignment is applied, moved to another role in place
+// and removed without errors.
+func TestAccAssignAccountRole_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAssignAccountRoleConfig(name, username, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_assign_account_role.test", &id),
+					resource.TestCheckResourceAttrPair("zenduty_assign_account_role.test", "account_role", "zenduty_account_role.first", "id"),
+				),
+			},
+			{
+				Config: testAccAssignAccountRoleConfig(name, username, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_assign_account_role.test", &id),
+					resource.TestCheckResourceAttrPair("zenduty_assign_account_role.test", "account_role", "zenduty_account_role.second", "id"),
+				),
+			},
+		},
+	})
+}
+
+// testAccAssignAccountRoleConfig declares two account roles and assigns the
+// one named role to username.
+func testAccAssignAccountRoleConfig(name, username, role string) string {
+	return fmt.Sprintf(`
+resource "zenduty_account_role" "first" {
+  name        = "%[1]s-first"
+  description = "Created by acceptance tests"
+  permissions = ["incident_read"]
+}
+
+resource "zenduty_account_role" "second" {
+  name        = "%[1]s-second"
+  description = "Created by acceptance tests"
+  permissions = ["incident_read", "schedule_read"]
+}
+
+resource "zenduty_assign_account_role" "test" {
+  account_role = zenduty_account_role.%[3]s.id
+  username     = %[2]q
+}
+`, name, username, role)
+}
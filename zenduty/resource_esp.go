@@ -2,13 +2,13 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -19,10 +19,12 @@ func resourceEsp() *schema.Resource {
 		CreateContext: resourceCreateEsp,
 		UpdateContext: resourceUpdateEsp,
 		DeleteContext: resourceDeleteEsp,
-		ReadContext:   wrapReadWith404(resourceReadEsp),
+		ReadContext:   resourceReadEsp,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourceEscalationPolicyImporter,
 		},
+		CustomizeDiff: resourceEspCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -94,7 +96,33 @@ func resourceEsp() *schema.Resource {
 	}
 }
 
+func resourceEspCustomizeDiff(Ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	return validateEspRules(d)
+}
+
+// validateEspRules checks that rule delays never decrease from one rule to
+// the next.
+func validateEspRules(d resourceGetter) error {
+	rules := d.Get("rules").([]interface{})
+	oldDelay := 0
+	for i, rule := range rules {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok || !valueKnown(d, fmt.Sprintf("rules.%d.delay", i)) {
+			continue
+		}
+		delay := ruleMap["delay"].(int)
+		if delay < oldDelay && i != 0 {
+			return cty.GetAttrPath("rules").IndexInt(i).GetAttr("delay").NewErrorf("delay must be greater than previous %d should be greater than %d", delay, oldDelay)
+		}
+		oldDelay = delay
+	}
+	return nil
+}
+
 func CreateEsp(Ctx context.Context, d *schema.ResourceData, m interface{}) (*client.EscalationPolicy, diag.Diagnostics) {
+	if err := validateEspRules(d); err != nil {
+		return nil, validationDiags(err)
+	}
 	newEsp := &client.EscalationPolicy{}
 	rules := d.Get("rules").([]interface{})
 	if v, ok := d.GetOk("name"); ok {
@@ -106,7 +134,7 @@ func CreateEsp(Ctx context.Context, d *schema.ResourceData, m interface{}) (*cli
 	if v, ok := d.GetOk("description"); ok {
 		newEsp.Description = v.(string)
 		if emptyString(newEsp.Description) {
-			return nil, diag.FromErr(errors.New("description is empty"))
+			return nil, attributeDiag(cty.GetAttrPath("description"), "description is empty")
 		}
 	}
 	if v, ok := d.GetOk("team_id"); ok {
@@ -119,21 +147,12 @@ func CreateEsp(Ctx context.Context, d *schema.ResourceData, m interface{}) (*cli
 		newEsp.MoveToNext = v.(bool)
 	}
 	newEsp.Rules = make([]client.Rules, len(rules))
-	oldDelay := 0
 	for i, rule := range rules {
 
 		ruleMap := rule.(map[string]interface{})
 		newRule := client.Rules{}
 		if v, ok := ruleMap["delay"]; ok {
-			if i == 0 && newRule.Delay != 0 {
-				return nil, diag.FromErr(errors.New("delay is not 0 for first rule"))
-			}
 			newRule.Delay = v.(int)
-			if newRule.Delay < oldDelay && i != 0 {
-				return nil, diag.Errorf("delay must be greater than previous %d should be greater than %d", newRule.Delay, oldDelay)
-			}
-			oldDelay = newRule.Delay
-
 		}
 		// Check if position was explicitly set by user
 		if v, exists := ruleMap["position"]; exists && v != nil {
@@ -219,7 +238,10 @@ func flattenTargets(targets []client.Targets) []map[string]interface{} {
 }
 
 func resourceCreateEsp(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	var diags diag.Diagnostics
 	newEsp, createErr := CreateEsp(Ctx, d, m)
@@ -230,7 +252,7 @@ func resourceCreateEsp(Ctx context.Context, d *schema.ResourceData, m interface{
 	esp, err := apiclient.Esp.CreateEscalationPolicy(newEsp.Team, newEsp)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(esp.UniqueID)
 
@@ -244,7 +266,10 @@ func resourceCreateEsp(Ctx context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceUpdateEsp(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	newEsp, createErr := CreateEsp(Ctx, d, m)
 	if createErr != nil {
@@ -253,31 +278,37 @@ func resourceUpdateEsp(Ctx context.Context, d *schema.ResourceData, m interface{
 	id := d.Id()
 	var diags diag.Diagnostics
 
-	_, err := apiclient.Esp.UpdateEscalationPolicy(newEsp.Team, id, newEsp)
+	_, err = apiclient.Esp.UpdateEscalationPolicy(newEsp.Team, id, newEsp)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	return diags
 }
 
 func resourceDeleteEsp(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	id := d.Id()
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	var diags diag.Diagnostics
-	err := apiclient.Esp.DeleteEscalationPolicy(teamID, id)
+	err = apiclient.Esp.DeleteEscalationPolicy(teamID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return diags
 }
 func resourceReadEsp(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	if v, ok := d.GetOk("team_id"); ok {
@@ -286,12 +317,12 @@ func resourceReadEsp(Ctx context.Context, d *schema.ResourceData, m interface{})
 
 	id := d.Id()
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required "))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	var diags diag.Diagnostics
 	esp, err := apiclient.Esp.GetEscalationPolicyByID(teamID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.Set("name", esp.Name)
 	d.Set("team_id", esp.Team)
@@ -0,0 +1,99 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccEsp_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_esp"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEspConfig(name, username, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_esp.test", &id),
+					resource.TestCheckResourceAttr("zenduty_esp.test", "name", name),
+					resource.TestCheckResourceAttr("zenduty_esp.test", "rules.#", "2"),
+					resource.TestCheckResourceAttr("zenduty_esp.test", "rules.1.delay", "5"),
+					resource.TestCheckResourceAttr("zenduty_esp.test", "rules.0.targets.0.target_id", username),
+				),
+			},
+			{
+				Config: testAccEspConfig(name, username, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_esp.test", &id),
+					resource.TestCheckResourceAttr("zenduty_esp.test", "repeat_policy", "2"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_esp.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_esp.test", "team_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccEspConfig adds an escalation policy paging username to the team
+// of testAccTeamConfig.
+func testAccEspConfig(name, username string, repeatPolicy int) string {
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_esp" "test" {
+  name          = %[1]q
+  summary       = "Created by acceptance tests"
+  team_id       = zenduty_teams.test.id
+  repeat_policy = %[3]d
+
+  rules {
+    delay = 0
+    targets {
+      target_type = 2
+      target_id   = %[2]q
+    }
+  }
+
+  rules {
+    delay = 5
+    targets {
+      target_type = 2
+      target_id   = %[2]q
+    }
+  }
+}
+`, name, username, repeatPolicy)
+}
+
+func TestValidateEspRules(t *testing.T) {
+	cases := []struct {
+		name     string
+		delays   []int
+		wantPath string
+	}{
+		{"no rules", nil, ""},
+		{"one rule", []int{10}, ""},
+		{"increasing", []int{0, 5, 10}, ""},
+		{"equal", []int{5, 5}, ""},
+		{"decreasing", []int{0, 10, 5}, "rules[2].delay"},
+		{"decreasing after the first", []int{10, 0}, "rules[1].delay"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rules := []interface{}{}
+			for _, delay := range c.delays {
+				rules = append(rules, map[string]interface{}{"delay": delay})
+			}
+			d := schema.TestResourceDataRaw(t, resourceEsp().Schema, map[string]interface{}{"rules": rules})
+			testCheckPathError(t, validateEspRules(d), c.wantPath, "delay must be greater than previous")
+		})
+	}
+}
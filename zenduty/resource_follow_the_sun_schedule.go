@@ -0,0 +1,650 @@
+package zenduty
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Zenduty/zenduty-go-sdk/client"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// A follow-the-sun schedule is a schedule with one layer per region, each on
+// call during fixed local hours of the region. The regions are compiled into
+// the layers and restrictions of zenduty_schedules, in the time zone of the
+// schedule.
+
+// followTheSunDays are the days a region can be on call, in the order of
+// start_day_of_week, from 1 for Monday to 7 for Sunday.
+var followTheSunDays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+const followTheSunDateFormat = "2006-01-02"
+
+var followTheSunTimeOfDay = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+func resourceFollowTheSunSchedule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCreateFollowTheSunSchedule,
+		ReadContext:   resourceReadFollowTheSunSchedule,
+		UpdateContext: resourceUpdateFollowTheSunSchedule,
+		DeleteContext: resourceDeleteSchedule,
+		Timeouts:      resourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			State: resourceFollowTheSunScheduleImporter,
+		},
+		CustomizeDiff: resourceFollowTheSunScheduleCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: ValidateUUID(),
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: ValidateRequired(),
+			},
+			"summary": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"time_zone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "UTC",
+				ValidateFunc: validateTimeZone,
+			},
+			"start_date": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) ([]string, []error) {
+					if _, err := time.Parse(followTheSunDateFormat, v.(string)); err != nil {
+						return nil, []error{fmt.Errorf("%s must be in the format YYYY-MM-DD", k)}
+					}
+					return nil, nil
+				},
+			},
+			"region": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: ValidateRequired(),
+						},
+						"time_zone": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateTimeZone,
+						},
+						"start_time": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(followTheSunTimeOfDay, "must be in the format HH:MM"),
+						},
+						"end_time": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(followTheSunTimeOfDay, "must be in the format HH:MM"),
+						},
+						"days": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(followTheSunDays, false),
+							},
+						},
+						"users": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"rotation_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      7,
+							ValidateFunc: validation.IntBetween(1, 365),
+						},
+					},
+				},
+			},
+			"layers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"unique_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"shift_length": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"rotation_start_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rotation_end_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"users": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"restriction_type": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"restrictions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"duration": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"start_day_of_week": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"start_time_of_day": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateTimeZone(v interface{}, k string) ([]string, []error) {
+	if _, err := time.LoadLocation(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// followTheSunRegion is a region of a follow-the-sun schedule, on call from
+// start to end after midnight in its time zone, on its days.
+type followTheSunRegion struct {
+	name         string
+	location     *time.Location
+	start, end   time.Duration
+	days         []time.Weekday
+	users        []string
+	rotationDays int
+}
+
+// onCallFor returns how long the region is on call from its start, a whole
+// day if it ends when it starts.
+func (r followTheSunRegion) onCallFor() time.Duration {
+	if r.end <= r.start {
+		return r.end - r.start + 24*time.Hour
+	}
+	return r.end - r.start
+}
+
+// startOn returns when the region is first on call on the date of day, or on
+// the first of its days after it.
+func (r followTheSunRegion) startOn(day time.Time, weekday time.Weekday) time.Time {
+	offset := (int(weekday) - int(day.Weekday()) + 7) % 7
+	return time.Date(day.Year(), day.Month(), day.Day()+offset, int(r.start/time.Hour), int(r.start%time.Hour/time.Minute), 0, 0, r.location)
+}
+
+// compile returns the layer of the region in a schedule in loc, rotating
+// from startDate. The local hours of the region are converted to loc at the
+// offsets of both on startDate.
+func (r followTheSunRegion) compile(loc *time.Location, startDate time.Time) client.CreateLayers {
+	start := r.startOn(startDate, startDate.Weekday())
+	layer := client.CreateLayers{
+		Name:              r.name,
+		ShiftLength:       r.rotationDays * 24 * 3600,
+		RotationStartTime: start.UTC().Format(time.RFC3339),
+	}
+	for _, user := range r.users {
+		layer.Users = append(layer.Users, client.CreateUserLayer{User: user})
+	}
+
+	duration := int(r.onCallFor() / time.Second)
+	if len(r.days) == 7 {
+		if duration == 24*3600 {
+			layer.RestrictionType = restrictionNone
+			return layer
+		}
+		layer.RestrictionType = restrictionDaily
+		layer.Restrictions = []client.Restrictions{{
+			Duration:       duration,
+			StartDayOfWeek: 7,
+			StartTimeOfDay: start.In(loc).Format("15:04:05"),
+		}}
+		return layer
+	}
+	layer.RestrictionType = restrictionWeekly
+	for _, weekday := range r.days {
+		local := r.startOn(startDate, weekday).In(loc)
+		layer.Restrictions = append(layer.Restrictions, client.Restrictions{
+			Duration:       duration,
+			StartDayOfWeek: (int(local.Weekday())+6)%7 + 1,
+			StartTimeOfDay: local.Format("15:04:05"),
+		})
+	}
+	return layer
+}
+
+// drift returns the first date within a year of startDate on which the
+// region starts at another time of day in loc than on startDate, because one
+// of the two changes clocks for daylight saving time, or the zero time if
+// there is none.
+func (r followTheSunRegion) drift(loc *time.Location, startDate time.Time) time.Time {
+	first := r.startOn(startDate, startDate.Weekday()).In(loc).Format("15:04")
+	for days := 1; days <= 366; days++ {
+		day := startDate.AddDate(0, 0, days)
+		if start := r.startOn(day, day.Weekday()); start.In(loc).Format("15:04") != first {
+			return start
+		}
+	}
+	return time.Time{}
+}
+
+// followTheSunRegions returns the regions in the configuration of a
+// follow-the-sun schedule.
+func followTheSunRegions(d resourceGetter) ([]followTheSunRegion, error) {
+	var regions []followTheSunRegion
+	names := map[string]bool{}
+	for i, v := range d.Get("region").([]interface{}) {
+		regionMap := v.(map[string]interface{})
+		path := cty.GetAttrPath("region").IndexInt(i)
+		region := followTheSunRegion{
+			name:         regionMap["name"].(string),
+			rotationDays: regionMap["rotation_days"].(int),
+		}
+		if names[region.name] {
+			return nil, path.GetAttr("name").NewErrorf("region names must be unique, %q is used more than once", region.name)
+		}
+		names[region.name] = true
+
+		var err error
+		if region.location, err = time.LoadLocation(regionMap["time_zone"].(string)); err != nil {
+			return nil, path.GetAttr("time_zone").NewError(err)
+		}
+		for _, key := range []string{"start_time", "end_time"} {
+			t, err := time.Parse("15:04", regionMap[key].(string))
+			if err != nil {
+				return nil, path.GetAttr(key).NewErrorf("must be in the format HH:MM")
+			}
+			offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+			if key == "start_time" {
+				region.start = offset
+			} else {
+				region.end = offset
+			}
+		}
+
+		days, _ := regionMap["days"].([]interface{})
+		if len(days) == 0 {
+			days = make([]interface{}, len(followTheSunDays))
+			for j, day := range followTheSunDays {
+				days[j] = day
+			}
+		}
+		seen := map[time.Weekday]bool{}
+		for j, day := range days {
+			weekday := -1
+			for k, name := range followTheSunDays {
+				if day.(string) == name {
+					weekday = (k + 1) % 7
+				}
+			}
+			if weekday < 0 {
+				return nil, path.GetAttr("days").IndexInt(j).NewErrorf("must be one of %v", followTheSunDays)
+			}
+			if !seen[time.Weekday(weekday)] {
+				seen[time.Weekday(weekday)] = true
+				region.days = append(region.days, time.Weekday(weekday))
+			}
+		}
+		// Order the days from Monday, like start_day_of_week.
+		sort.Slice(region.days, func(a, b int) bool {
+			return (region.days[a]+6)%7 < (region.days[b]+6)%7
+		})
+
+		for _, user := range regionMap["users"].([]interface{}) {
+			region.users = append(region.users, user.(string))
+		}
+		regions = append(regions, region)
+	}
+	return regions, nil
+}
+
+// followTheSunRegionsKnown reports whether every value of the regions of a
+// follow-the-sun schedule is known.
+func followTheSunRegionsKnown(d resourceGetter) bool {
+	if !valueKnown(d, "region") {
+		return false
+	}
+	for i, region := range d.Get("region").([]interface{}) {
+		regionMap, _ := region.(map[string]interface{})
+		keys := []string{"name", "time_zone", "start_time", "end_time", "days", "users", "rotation_days"}
+		days, _ := regionMap["days"].([]interface{})
+		for j := range days {
+			keys = append(keys, fmt.Sprintf("days.%d", j))
+		}
+		users, _ := regionMap["users"].([]interface{})
+		for j := range users {
+			keys = append(keys, fmt.Sprintf("users.%d", j))
+		}
+		for _, key := range keys {
+			if !valueKnown(d, fmt.Sprintf("region.%d.%s", i, key)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func resourceFollowTheSunScheduleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !followTheSunRegionsKnown(d) {
+		return nil
+	}
+	regions, err := followTheSunRegions(d)
+	if err != nil {
+		return err
+	}
+	compiled := compileFollowTheSunRegions(d, regions)
+	if d.HasChange("region") || d.HasChange("time_zone") || d.HasChange("start_date") {
+		return d.SetNewComputed("layers")
+	}
+	// Layers edited in Zenduty no longer match their regions, and are
+	// compiled again.
+	if compiled != nil && !followTheSunLayersMatch(compiled, d.Get("time_zone").(string), d.Get("layers").([]interface{})) {
+		return d.SetNewComputed("layers")
+	}
+	return nil
+}
+
+// compileFollowTheSunRegions compiles the regions of a follow-the-sun
+// schedule being planned into its layers. It returns nil while the time zone
+// or start date are not known. A CustomizeDiff function cannot return
+// warnings, so the regions whose handoffs move over the year are only written
+// to the provider log here; buildFollowTheSunSchedule warns about them at
+// apply time.
+func compileFollowTheSunRegions(d *schema.ResourceDiff, regions []followTheSunRegion) []client.CreateLayers {
+	if !valueKnown(d, "time_zone") || !valueKnown(d, "start_date") {
+		return nil
+	}
+	loc, err := time.LoadLocation(d.Get("time_zone").(string))
+	if err != nil {
+		return nil
+	}
+	startDate, err := time.ParseInLocation(followTheSunDateFormat, d.Get("start_date").(string), loc)
+	if err != nil {
+		return nil
+	}
+	layers := make([]client.CreateLayers, len(regions))
+	for i, region := range regions {
+		layers[i] = region.compile(loc, startDate)
+		if when := region.drift(loc, startDate); !when.IsZero() {
+			log.Printf("[WARN] %s", followTheSunDriftWarning(region, loc, when))
+		}
+	}
+	return layers
+}
+
+// followTheSunLayersMatch reports whether the layers read from a schedule in
+// timeZone are the layers its regions compile to. Times are compared by the
+// instant or time of day they denote, whatever format the API returns them in.
+func followTheSunLayersMatch(compiled []client.CreateLayers, timeZone string, layers []interface{}) bool {
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil || len(layers) != len(compiled) {
+		return false
+	}
+	for i, layer := range compiled {
+		read, _ := layers[i].(map[string]interface{})
+		if read == nil ||
+			read["name"] != layer.Name ||
+			read["shift_length"] != layer.ShiftLength ||
+			!sameTimestamp(read["rotation_start_time"], layer.RotationStartTime, loc) ||
+			!sameTimestamp(read["rotation_end_time"], layer.RotationEndTime, loc) ||
+			read["restriction_type"] != layer.RestrictionType {
+			return false
+		}
+		users, _ := read["users"].([]interface{})
+		if len(users) != len(layer.Users) {
+			return false
+		}
+		for j, user := range layer.Users {
+			if users[j] != user.User {
+				return false
+			}
+		}
+		restrictions, _ := read["restrictions"].([]interface{})
+		if len(restrictions) != len(layer.Restrictions) {
+			return false
+		}
+		for j, restriction := range layer.Restrictions {
+			readRestriction, _ := restrictions[j].(map[string]interface{})
+			if readRestriction == nil ||
+				readRestriction["duration"] != restriction.Duration ||
+				readRestriction["start_day_of_week"] != restriction.StartDayOfWeek ||
+				!sameTimeOfDay(readRestriction["start_time_of_day"], restriction.StartTimeOfDay) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sameTimestamp reports whether the timestamp read, an RFC 3339 timestamp or
+// a local time in loc, is the instant of the RFC 3339 timestamp want.
+func sameTimestamp(read interface{}, want string, loc *time.Location) bool {
+	s, _ := read.(string)
+	if s == "" || want == "" {
+		return s == want
+	}
+	readTime, err := parseTimestamp(s, loc)
+	if err != nil {
+		return false
+	}
+	wantTime, err := time.Parse(time.RFC3339, want)
+	return err == nil && readTime.Equal(wantTime)
+}
+
+// sameTimeOfDay reports whether the time of day read is want, taking
+// HH:MM:SS and HH:MM as the same time.
+func sameTimeOfDay(read interface{}, want string) bool {
+	s, _ := read.(string)
+	readTime, err := parseTimeOfDay(s)
+	if err != nil {
+		return false
+	}
+	wantTime, err := parseTimeOfDay(want)
+	return err == nil && readTime == wantTime
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04:05", s)
+	if err != nil {
+		if t, err = time.Parse("15:04", s); err != nil {
+			return 0, err
+		}
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second, nil
+}
+
+func followTheSunDriftWarning(region followTheSunRegion, loc *time.Location, when time.Time) string {
+	return fmt.Sprintf("region %q is on call from %s in %s, which is at another time in %s from %s, when one of the two changes clocks for daylight saving time; the layer keeps the time it has on start_date, so its handoffs move by the difference",
+		region.name, when.In(region.location).Format("15:04"), region.location, loc, when.Format(followTheSunDateFormat))
+}
+
+// buildFollowTheSunSchedule compiles the configuration of a follow-the-sun
+// schedule into a schedule. It warns about regions whose handoffs move over
+// the year.
+func buildFollowTheSunSchedule(d *schema.ResourceData) (*client.CreateSchedule, diag.Diagnostics) {
+	timeZone := d.Get("time_zone").(string)
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, attributeDiag(cty.GetAttrPath("time_zone"), err.Error())
+	}
+	startDate, err := time.ParseInLocation(followTheSunDateFormat, d.Get("start_date").(string), loc)
+	if err != nil {
+		return nil, attributeDiag(cty.GetAttrPath("start_date"), "start_date must be in the format YYYY-MM-DD")
+	}
+	regions, err := followTheSunRegions(d)
+	if err != nil {
+		return nil, validationDiags(err)
+	}
+
+	var diags diag.Diagnostics
+	newSchedule := &client.CreateSchedule{
+		Name:        d.Get("name").(string),
+		Summary:     d.Get("summary").(string),
+		Description: d.Get("description").(string),
+		TimeZone:    timeZone,
+		Team:        d.Get("team_id").(string),
+		Overrides:   []client.Overrides{},
+	}
+	for i, region := range regions {
+		newSchedule.Layers = append(newSchedule.Layers, region.compile(loc, startDate))
+		if when := region.drift(loc, startDate); !when.IsZero() {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       "Region handoffs move with daylight saving time",
+				Detail:        followTheSunDriftWarning(region, loc, when) + ".",
+				AttributePath: cty.GetAttrPath("region").IndexInt(i),
+			})
+		}
+	}
+	return newSchedule, diags
+}
+
+func resourceCreateFollowTheSunSchedule(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	newSchedule, diags := buildFollowTheSunSchedule(d)
+	if diags.HasError() {
+		return diags
+	}
+	schedule, err := apiclient.Schedules.CreateSchedule(newSchedule.Team, newSchedule)
+	if err != nil {
+		return apiErrorDiags(err)
+	}
+	d.SetId(schedule.UniqueID)
+	return append(diags, resourceReadFollowTheSunSchedule(ctx, d, m)...)
+}
+
+func resourceUpdateFollowTheSunSchedule(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	teamID := d.Get("team_id").(string)
+	newSchedule, diags := buildFollowTheSunSchedule(d)
+	if diags.HasError() {
+		return diags
+	}
+	// Send every layer with the unique_id of the layer of its region, so the
+	// API updates it in place and keeps its rotation.
+	oldLayers, _ := d.GetChange("layers")
+	keys := make([]scheduleLayerKey, len(newSchedule.Layers))
+	for i, layer := range newSchedule.Layers {
+		keys[i].name = layer.Name
+	}
+	matches := matchScheduleLayers(keys, scheduleLayerKeys(oldLayers.([]interface{}), true))
+	update := scheduleUpdate{CreateSchedule: newSchedule}
+	for i, layer := range newSchedule.Layers {
+		update.Layers = append(update.Layers, scheduleLayerUpdate{CreateLayers: layer})
+		if j := matches[i]; j >= 0 {
+			update.Layers[i].UniqueID = oldLayers.([]interface{})[j].(map[string]interface{})["unique_id"].(string)
+		}
+	}
+	// The schedule is updated as a whole, so send back its overrides, which
+	// are managed elsewhere, such as by zenduty_schedule_override.
+	current, err := apiclient.Schedules.GetScheduleByID(teamID, d.Id())
+	if err != nil {
+		return apiErrorDiags(err)
+	}
+	newSchedule.Overrides = current.Overrides
+	if err := m.(*Meta).request(ctx, http.MethodPut, schedulePath(teamID, d.Id()), update, nil); err != nil {
+		return apiErrorDiags(err)
+	}
+	return append(diags, resourceReadFollowTheSunSchedule(ctx, d, m)...)
+}
+
+func resourceReadFollowTheSunSchedule(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	schedule, err := apiclient.Schedules.GetScheduleByID(d.Get("team_id").(string), d.Id())
+	if err != nil {
+		return handleReadError(d, err)
+	}
+	d.Set("name", schedule.Name)
+	d.Set("summary", schedule.Summary)
+	d.Set("description", schedule.Description)
+	d.Set("time_zone", schedule.TimeZone)
+	// The regions are not part of the schedule, so only the layers they
+	// compile to are read, ordered like the regions.
+	var regions []interface{}
+	for _, region := range d.Get("region").([]interface{}) {
+		regions = append(regions, map[string]interface{}{"name": region.(map[string]interface{})["name"]})
+	}
+	layers := orderScheduleLayers(schedule.Layers, regions)
+	if err := d.Set("layers", flattenLayer(schedule.TimeZone, layers)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// resourceFollowTheSunScheduleImporter imports a schedule by
+// <team_id>/<schedule_id>. The regions are not part of the schedule, so they
+// are left to the configuration, and only the layers are read.
+func resourceFollowTheSunScheduleImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("unexpected format of id (%q), expected <team_id>/<schedule_id>", d.Id())
+	} else if !IsValidUUID(parts[0]) {
+		return nil, fmt.Errorf("invalid team_id (%q)", parts[0])
+	} else if !IsValidUUID(parts[1]) {
+		return nil, fmt.Errorf("invalid schedule_id (%q)", parts[1])
+	}
+	d.Set("team_id", parts[0])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,358 @@
+package zenduty
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Zenduty/zenduty-go-sdk/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccFollowTheSunSchedule_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	var id, teamID, layerID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_follow_the_sun_schedule"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFollowTheSunScheduleConfig(name, username, "17:00"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_follow_the_sun_schedule.test", &id),
+					resource.TestCheckResourceAttr("zenduty_follow_the_sun_schedule.test", "layers.#", "2"),
+					resource.TestCheckResourceAttr("zenduty_follow_the_sun_schedule.test", "layers.0.name", "APAC"),
+					resource.TestCheckResourceAttr("zenduty_follow_the_sun_schedule.test", "layers.0.rotation_start_time", "2030-01-07 03:30"),
+					resource.TestCheckResourceAttr("zenduty_follow_the_sun_schedule.test", "layers.0.shift_length", "604800"),
+					resource.TestCheckResourceAttr("zenduty_follow_the_sun_schedule.test", "layers.0.restriction_type", "1"),
+					resource.TestCheckResourceAttr("zenduty_follow_the_sun_schedule.test", "layers.0.restrictions.0.start_time_of_day", "03:30:00"),
+					resource.TestCheckResourceAttr("zenduty_follow_the_sun_schedule.test", "layers.0.restrictions.0.duration", "28800"),
+					resource.TestCheckResourceAttr("zenduty_follow_the_sun_schedule.test", "layers.1.name", "EMEA"),
+					resource.TestCheckResourceAttr("zenduty_follow_the_sun_schedule.test", "layers.1.restriction_type", "2"),
+					resource.TestCheckResourceAttr("zenduty_follow_the_sun_schedule.test", "layers.1.restrictions.#", "5"),
+					resource.TestCheckResourceAttr("zenduty_follow_the_sun_schedule.test", "layers.1.restrictions.4.start_day_of_week", "5"),
+					testAccCheckScheduleLayerID("zenduty_follow_the_sun_schedule.test", 1, &layerID),
+				),
+			},
+			{
+				// Changing the hours of a region keeps its layer.
+				Config: testAccFollowTheSunScheduleConfig(name, username, "18:00"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_follow_the_sun_schedule.test", &id),
+					resource.TestCheckResourceAttr("zenduty_follow_the_sun_schedule.test", "layers.1.restrictions.0.duration", "32400"),
+					testAccCheckScheduleLayerID("zenduty_follow_the_sun_schedule.test", 1, &layerID),
+					testAccCheckFollowTheSunScheduleTeam("zenduty_follow_the_sun_schedule.test", &teamID),
+				),
+			},
+			{
+				// A layer edited in Zenduty is compiled from its region again.
+				PreConfig: testAccEditFollowTheSunScheduleLayer(t, &teamID, &id, 1),
+				Config:    testAccFollowTheSunScheduleConfig(name, username, "18:00"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_follow_the_sun_schedule.test", &id),
+					resource.TestCheckResourceAttr("zenduty_follow_the_sun_schedule.test", "layers.1.shift_length", "604800"),
+					testAccCheckScheduleLayerID("zenduty_follow_the_sun_schedule.test", 1, &layerID),
+				),
+			},
+			{
+				// The regions are not part of the schedule and cannot be
+				// imported.
+				ResourceName:            "zenduty_follow_the_sun_schedule.test",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccImportStateIDFunc("zenduty_follow_the_sun_schedule.test", "team_id"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"region", "start_date"},
+			},
+		},
+	})
+}
+
+// testAccCheckFollowTheSunScheduleTeam stores the team_id of the schedule
+// resourceName in teamID.
+func testAccCheckFollowTheSunScheduleTeam(resourceName string, teamID *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+		*teamID = rs.Primary.Attributes["team_id"]
+		return nil
+	}
+}
+
+// testAccEditFollowTheSunScheduleLayer changes the shift length of the layer
+// at index of the schedule id of the team teamID through the API, the way an
+// edit in Zenduty would.
+func testAccEditFollowTheSunScheduleLayer(t *testing.T, teamID, id *string, index int) func() {
+	return func() {
+		meta, err := testAccMeta()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx := context.Background()
+		var schedule map[string]interface{}
+		if err := meta.request(ctx, http.MethodGet, schedulePath(*teamID, *id), nil, &schedule); err != nil {
+			t.Fatal(err)
+		}
+		layers, _ := schedule["layers"].([]interface{})
+		if len(layers) <= index {
+			t.Fatalf("schedule %s has %d layers, want more than %d", *id, len(layers), index)
+		}
+		layers[index].(map[string]interface{})["shift_length"] = 86400
+		if err := meta.request(ctx, http.MethodPut, schedulePath(*teamID, *id), schedule, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// testAccFollowTheSunScheduleConfig adds a follow-the-sun schedule to the
+// team of testAccTeamConfig, with username on call in APAC every day and in
+// EMEA on weekdays until emeaEndTime.
+func testAccFollowTheSunScheduleConfig(name, username, emeaEndTime string) string {
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_follow_the_sun_schedule" "test" {
+  name       = %[1]q
+  team_id    = zenduty_teams.test.id
+  start_date = "2030-01-07"
+
+  region {
+    name       = "APAC"
+    time_zone  = "Asia/Kolkata"
+    start_time = "09:00"
+    end_time   = "17:00"
+    users      = [%[2]q]
+  }
+
+  region {
+    name       = "EMEA"
+    time_zone  = "Europe/London"
+    start_time = "09:00"
+    end_time   = %[3]q
+    days       = ["mon", "tue", "wed", "thu", "fri"]
+    users      = [%[2]q]
+  }
+}
+`, name, username, emeaEndTime)
+}
+
+func TestFollowTheSunRegionCompile(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	startDate := time.Date(2030, time.January, 7, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name   string
+		region followTheSunRegion
+		want   client.CreateLayers
+	}{
+		{
+			name: "all day",
+			region: followTheSunRegion{
+				name:         "Everyone",
+				location:     time.UTC,
+				days:         []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday},
+				users:        []string{"alice"},
+				rotationDays: 7,
+			},
+			want: client.CreateLayers{
+				Name:              "Everyone",
+				ShiftLength:       604800,
+				RotationStartTime: "2030-01-07T00:00:00Z",
+				Users:             []client.CreateUserLayer{{User: "alice"}},
+				RestrictionType:   restrictionNone,
+			},
+		},
+		{
+			name: "daily across midnight",
+			region: followTheSunRegion{
+				name:         "Americas",
+				location:     newYork,
+				start:        18 * time.Hour,
+				end:          2 * time.Hour,
+				days:         []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday},
+				users:        []string{"alice", "bob"},
+				rotationDays: 1,
+			},
+			want: client.CreateLayers{
+				Name:              "Americas",
+				ShiftLength:       86400,
+				RotationStartTime: "2030-01-07T23:00:00Z",
+				Users:             []client.CreateUserLayer{{User: "alice"}, {User: "bob"}},
+				RestrictionType:   restrictionDaily,
+				Restrictions:      []client.Restrictions{{Duration: 28800, StartDayOfWeek: 7, StartTimeOfDay: "23:00:00"}},
+			},
+		},
+		{
+			name: "weekly across days",
+			region: followTheSunRegion{
+				name:         "Weekend nights",
+				location:     newYork,
+				start:        22 * time.Hour,
+				end:          6 * time.Hour,
+				days:         []time.Weekday{time.Saturday, time.Sunday},
+				users:        []string{"alice"},
+				rotationDays: 14,
+			},
+			want: client.CreateLayers{
+				Name:              "Weekend nights",
+				ShiftLength:       1209600,
+				RotationStartTime: "2030-01-08T03:00:00Z",
+				Users:             []client.CreateUserLayer{{User: "alice"}},
+				RestrictionType:   restrictionWeekly,
+				Restrictions: []client.Restrictions{
+					{Duration: 28800, StartDayOfWeek: 7, StartTimeOfDay: "03:00:00"},
+					{Duration: 28800, StartDayOfWeek: 1, StartTimeOfDay: "03:00:00"},
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		if got := c.region.compile(time.UTC, startDate); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: compile() = %+v, want %+v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestFollowTheSunRegionDrift(t *testing.T) {
+	startDate := time.Date(2030, time.January, 7, 0, 0, 0, 0, time.UTC)
+	for _, c := range []struct {
+		timeZone string
+		want     string
+	}{
+		{"Asia/Kolkata", ""},
+		{"America/New_York", "2030-03-10"},
+		{"Europe/London", "2030-03-31"},
+	} {
+		loc, err := time.LoadLocation(c.timeZone)
+		if err != nil {
+			t.Fatal(err)
+		}
+		region := followTheSunRegion{name: c.timeZone, location: loc, start: 9 * time.Hour, end: 17 * time.Hour}
+		got := ""
+		if when := region.drift(time.UTC, startDate); !when.IsZero() {
+			got = when.Format(followTheSunDateFormat)
+		}
+		if got != c.want {
+			t.Errorf("%s: drift() = %q, want %q", c.timeZone, got, c.want)
+		}
+	}
+}
+
+func TestFollowTheSunLayersMatch(t *testing.T) {
+	startDate := time.Date(2030, time.January, 7, 0, 0, 0, 0, time.UTC)
+	region := followTheSunRegion{
+		name:         "EMEA",
+		location:     time.UTC,
+		start:        9 * time.Hour,
+		end:          17 * time.Hour,
+		days:         []time.Weekday{time.Monday, time.Friday},
+		users:        []string{"alice", "bob"},
+		rotationDays: 7,
+	}
+	compiled := []client.CreateLayers{region.compile(time.UTC, startDate)}
+
+	// read returns the layers of compiled as Read puts them in state, after
+	// edit changes the layer the API returns.
+	read := func(edit func(layer *client.Layers)) []interface{} {
+		layer := client.Layers{
+			UniqueID:          "a1b2c3d4-0000-4000-8000-000000000000",
+			Name:              compiled[0].Name,
+			ShiftLength:       compiled[0].ShiftLength,
+			RotationStartTime: compiled[0].RotationStartTime,
+			RestrictionType:   compiled[0].RestrictionType,
+			Restrictions:      append([]client.Restrictions(nil), compiled[0].Restrictions...),
+		}
+		for _, user := range compiled[0].Users {
+			layer.Users = append(layer.Users, client.Users{User: user.User})
+		}
+		edit(&layer)
+		var layers []interface{}
+		for _, flat := range flattenLayer("Asia/Kolkata", []client.Layers{layer}) {
+			var users, restrictions []interface{}
+			for _, user := range flat["users"].([]string) {
+				users = append(users, user)
+			}
+			for _, restriction := range flat["restrictions"].([]map[string]interface{}) {
+				restrictions = append(restrictions, restriction)
+			}
+			flat["users"], flat["restrictions"] = users, restrictions
+			layers = append(layers, flat)
+		}
+		return layers
+	}
+
+	cases := []struct {
+		name string
+		edit func(layer *client.Layers)
+		want bool
+	}{
+		{"unchanged", func(layer *client.Layers) {}, true},
+		{"users", func(layer *client.Layers) { layer.Users = layer.Users[:1] }, false},
+		{"shift length", func(layer *client.Layers) { layer.ShiftLength = 86400 }, false},
+		{"rotation start", func(layer *client.Layers) { layer.RotationStartTime = "2030-01-08T09:00:00Z" }, false},
+		{"restriction", func(layer *client.Layers) { layer.Restrictions[1].StartTimeOfDay = "10:00:00" }, false},
+		{"restriction removed", func(layer *client.Layers) { layer.Restrictions = layer.Restrictions[:1] }, false},
+	}
+	for _, c := range cases {
+		if got := followTheSunLayersMatch(compiled, "Asia/Kolkata", read(c.edit)); got != c.want {
+			t.Errorf("%s: followTheSunLayersMatch() = %t, want %t", c.name, got, c.want)
+		}
+	}
+	if followTheSunLayersMatch(compiled, "Asia/Kolkata", nil) {
+		t.Error("followTheSunLayersMatch() with no layers read = true, want false")
+	}
+}
+
+func TestFollowTheSunLayersMatch_apiFormats(t *testing.T) {
+	compiled := []client.CreateLayers{{
+		Name:              "APAC",
+		ShiftLength:       604800,
+		RotationStartTime: "2030-01-07T03:30:00Z",
+		Users:             []client.CreateUserLayer{{User: "alice"}},
+		RestrictionType:   restrictionWeekly,
+		Restrictions:      []client.Restrictions{{Duration: 28800, StartDayOfWeek: 1, StartTimeOfDay: "09:00:00"}},
+	}}
+	read := func(rotationStart, rotationEnd, startTimeOfDay string) []interface{} {
+		return []interface{}{map[string]interface{}{
+			"name":                "APAC",
+			"shift_length":        604800,
+			"rotation_start_time": rotationStart,
+			"rotation_end_time":   rotationEnd,
+			"restriction_type":    restrictionWeekly,
+			"users":               []interface{}{"alice"},
+			"restrictions": []interface{}{map[string]interface{}{
+				"duration":          28800,
+				"start_day_of_week": 1,
+				"start_time_of_day": startTimeOfDay,
+			}},
+		}}
+	}
+
+	cases := []struct {
+		name                                       string
+		rotationStart, rotationEnd, startTimeOfDay string
+		want                                       bool
+	}{
+		{"local time", "2030-01-07 09:00", "", "09:00:00", true},
+		{"UTC offset", "2030-01-07T03:30:00+00:00", "", "09:00:00", true},
+		{"local offset", "2030-01-07T09:00:00+05:30", "", "09:00:00", true},
+		{"fractional seconds", "2030-01-07T03:30:00.000000Z", "", "09:00:00", true},
+		{"time of day without seconds", "2030-01-07 09:00", "", "09:00", true},
+		{"another instant", "2030-01-07T03:31:00+00:00", "", "09:00:00", false},
+		{"another time of day", "2030-01-07 09:00", "", "09:00:30", false},
+		{"rotation end added", "2030-01-07 09:00", "2030-02-01 00:00", "09:00:00", false},
+		{"invalid time of day", "2030-01-07 09:00", "", "9 am", false},
+	}
+	for _, c := range cases {
+		if got := followTheSunLayersMatch(compiled, "Asia/Kolkata", read(c.rotationStart, c.rotationEnd, c.startTimeOfDay)); got != c.want {
+			t.Errorf("%s: followTheSunLayersMatch() = %t, want %t", c.name, got, c.want)
+		}
+	}
+}
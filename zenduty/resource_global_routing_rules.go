@@ -2,11 +2,11 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -17,7 +17,8 @@ func resourceGlobalRoutingRules() *schema.Resource {
 		CreateContext: resourceCreateRoutingRules,
 		UpdateContext: resourceUpdateRoutingRules,
 		DeleteContext: resourceDeleteRoutingRules,
-		ReadContext:   wrapReadWith404(resourceReadRoutingRules),
+		ReadContext:   resourceReadRoutingRules,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourceRouterRulesImporter,
 		},
@@ -60,6 +61,7 @@ func CreateRoutingRuleAction(Ctx context.Context, d *schema.ResourceData, m inte
 	for i, action := range actions {
 		ruleMap := action.(map[string]interface{})
 		newAction := client.GlobalRoutingRuleAction{}
+		path := cty.GetAttrPath("actions").IndexInt(i)
 
 		if v, ok := ruleMap["action_type"]; ok {
 			newAction.ActionType = v.(int)
@@ -70,7 +72,7 @@ func CreateRoutingRuleAction(Ctx context.Context, d *schema.ResourceData, m inte
 				newAction.Integration = v.(string)
 			}
 			if newAction.Integration == "" {
-				return nil, diag.FromErr(errors.New("integration is required"))
+				return nil, attributeDiag(path.GetAttr("integration"), "integration is required")
 			}
 
 		}
@@ -94,13 +96,13 @@ func ValidateAndCreateRoutingRules(Ctx context.Context, d *schema.ResourceData,
 
 	}
 	if newAlertRule.Name == "" {
-		return nil, diag.FromErr(errors.New("description is required"))
+		return nil, attributeDiag(cty.GetAttrPath("name"), "name is required")
 	}
 	if newAlertRule.RuleJSON == "" {
-		return nil, diag.FromErr(errors.New("rule_json is required"))
+		return nil, attributeDiag(cty.GetAttrPath("rule_json"), "rule_json is required")
 	}
 	if !isJSONString(newAlertRule.RuleJSON) {
-		return nil, diag.FromErr(errors.New("rule_json is not valid JSON"))
+		return nil, attributeDiag(cty.GetAttrPath("rule_json"), "rule_json is not valid JSON")
 	}
 	actions, actionErr := CreateRoutingRuleAction(Ctx, d, m, newAlertRule)
 	if actionErr != nil {
@@ -113,7 +115,10 @@ func ValidateAndCreateRoutingRules(Ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceCreateRoutingRules(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	var diags diag.Diagnostics
 	var routerID string
 
@@ -128,14 +133,17 @@ func resourceCreateRoutingRules(Ctx context.Context, d *schema.ResourceData, m i
 	alertRule, err := apiclient.GlobalRouter.CreateGlobalRoutingRule(routerID, newRule)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(alertRule.UniqueID)
 	return diags
 }
 
 func resourceUpdateRoutingRules(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	var diags diag.Diagnostics
 
 	var routerID string
@@ -148,22 +156,25 @@ func resourceUpdateRoutingRules(Ctx context.Context, d *schema.ResourceData, m i
 		return ruleErr
 	}
 
-	_, err := apiclient.GlobalRouter.UpdateGlobalRoutingRule(routerID, d.Id(), newRule)
+	_, err = apiclient.GlobalRouter.UpdateGlobalRoutingRule(routerID, d.Id(), newRule)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	return diags
 }
 
 func resourceReadRoutingRules(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	var diags diag.Diagnostics
 
 	routerID := d.Get("router_id").(string)
 	rule, err := apiclient.GlobalRouter.GetGlobalRoutingRule(routerID, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.SetId(rule.UniqueID)
 	if rule.RuleJSON != "" {
@@ -195,12 +206,15 @@ func flattenRoutingActions(rule *client.GlobalRoutingRule) []map[string]interfac
 
 func resourceDeleteRoutingRules(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	routerID, uniqueID := d.Get("router_id").(string), d.Id()
 
-	err := apiclient.GlobalRouter.DeleteGlobalRoutingRule(routerID, uniqueID)
+	err = apiclient.GlobalRouter.DeleteGlobalRoutingRule(routerID, uniqueID)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return diags
 }
@@ -0,0 +1,61 @@
+package zenduty
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGlobalRoutingRule_basic(t *testing.T) {
+	name := testAccName()
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_globalrouting_rule"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalRoutingRuleConfig(name, "critical"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_globalrouting_rule.test", &id),
+					resource.TestCheckResourceAttrPair("zenduty_globalrouting_rule.test", "router_id", "zenduty_globalrouter.test", "id"),
+					resource.TestCheckResourceAttr("zenduty_globalrouting_rule.test", "actions.0.action_type", "1"),
+				),
+			},
+			{
+				Config: testAccGlobalRoutingRuleConfig(name, "warning"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_globalrouting_rule.test", &id),
+					resource.TestCheckResourceAttr("zenduty_globalrouting_rule.test", "name", name),
+					resource.TestMatchResourceAttr("zenduty_globalrouting_rule.test", "rule_json", regexp.MustCompile(`"value":"warning"`)),
+				),
+			},
+			{
+				ResourceName:      "zenduty_globalrouting_rule.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_globalrouting_rule.test", "router_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccGlobalRoutingRuleConfig declares a global router with a rule that
+// suppresses alerts whose summary contains value.
+func testAccGlobalRoutingRuleConfig(name, value string) string {
+	return testAccGlobalRouterConfig(name, true) + fmt.Sprintf(`
+resource "zenduty_globalrouting_rule" "test" {
+  router_id = zenduty_globalrouter.test.id
+  name      = %q
+  rule_json = jsonencode({
+    conditions = [{ key = "payload.summary", operator = "contains", value = %q }]
+  })
+
+  actions {
+    action_type = 1
+  }
+}
+`, name, value)
+}
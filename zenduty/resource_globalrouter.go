@@ -13,7 +13,8 @@ func resourceGlobalRouter() *schema.Resource {
 		CreateContext: resourceGlobalRouterCreate,
 		UpdateContext: resourceGlobalRouterUpdate,
 		DeleteContext: resourceGlobalRouterDelete,
-		ReadContext:   wrapReadWith404(resourceGlobalRouterRead),
+		ReadContext:   resourceGlobalRouterRead,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -40,7 +41,10 @@ func resourceGlobalRouter() *schema.Resource {
 }
 
 func resourceGlobalRouterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	newGlobalRouter := &client.GlobalRouterPayload{}
 	var diags diag.Diagnostics
@@ -57,7 +61,7 @@ func resourceGlobalRouterCreate(ctx context.Context, d *schema.ResourceData, m i
 
 	router, err := apiclient.GlobalRouter.CreateGlobalRouter(newGlobalRouter)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(router.UniqueID)
 	// added integration_key in response output
@@ -69,7 +73,10 @@ func resourceGlobalRouterCreate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceGlobalRouterUpdate(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	newGlobalRouter := &client.GlobalRouterPayload{}
 	var diags diag.Diagnostics
@@ -86,7 +93,7 @@ func resourceGlobalRouterUpdate(Ctx context.Context, d *schema.ResourceData, m i
 
 	router, err := apiclient.GlobalRouter.UpdateGlobalRouter(d.Id(), newGlobalRouter)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(router.UniqueID)
 	// added integration_key in response output
@@ -98,13 +105,16 @@ func resourceGlobalRouterUpdate(Ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceGlobalRouterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	id := d.Id()
 	var diags diag.Diagnostics
-	err := apiclient.GlobalRouter.DeleteGlobalRouter(id)
+	err = apiclient.GlobalRouter.DeleteGlobalRouter(id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 
 	return diags
@@ -114,11 +124,14 @@ func resourceGlobalRouterRead(ctx context.Context, d *schema.ResourceData, m int
 
 	var diags diag.Diagnostics
 	id := d.Id()
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	router, err := apiclient.GlobalRouter.GetGlobalRouter(id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.Set("name", router.Name)
 	d.Set("integration_key", router.IntegrationKey)
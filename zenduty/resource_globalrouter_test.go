@@ -0,0 +1,51 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGlobalRouter_basic(t *testing.T) {
+	name := testAccName()
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_globalrouter"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalRouterConfig(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_globalrouter.test", &id),
+					resource.TestCheckResourceAttr("zenduty_globalrouter.test", "name", name),
+					resource.TestCheckResourceAttr("zenduty_globalrouter.test", "is_enabled", "true"),
+				),
+			},
+			{
+				Config: testAccGlobalRouterConfig(name, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_globalrouter.test", &id),
+					resource.TestCheckResourceAttr("zenduty_globalrouter.test", "is_enabled", "false"),
+					resource.TestCheckResourceAttr("zenduty_globalrouter.test", "description", "Created by acceptance tests"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_globalrouter.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccGlobalRouterConfig(name string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "zenduty_globalrouter" "test" {
+  name        = %q
+  description = "Created by acceptance tests"
+  is_enabled  = %t
+}
+`, name, enabled)
+}
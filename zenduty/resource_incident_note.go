@@ -0,0 +1,117 @@
+package zenduty
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// incidentNumberPattern matches the IDs of zenduty_incidents.
+var incidentNumberPattern = regexp.MustCompile(`^[0-9]+$`)
+
+// incidentNote is a note on the timeline of an incident. The SDK does not
+// cover incident notes, so they are sent with Meta.request.
+type incidentNote struct {
+	UniqueID     string `json:"unique_id,omitempty"`
+	Note         string `json:"note"`
+	CreationDate string `json:"creation_date,omitempty"`
+}
+
+func incidentNotesPath(incidentNumber string) string {
+	return fmt.Sprintf("/api/incidents/%s/note/", incidentNumber)
+}
+
+func incidentNotePath(incidentNumber, id string) string {
+	return fmt.Sprintf("/api/incidents/%s/note/%s/", incidentNumber, id)
+}
+
+func resourceIncidentNote() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCreateIncidentNote,
+		ReadContext:   resourceReadIncidentNote,
+		UpdateContext: resourceUpdateIncidentNote,
+		DeleteContext: resourceDeleteIncidentNote,
+		Timeouts:      resourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			State: resourceIncidentNoteImporter,
+		},
+		Schema: map[string]*schema.Schema{
+			"incident_number": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(incidentNumberPattern, "must be an incident number"),
+			},
+			"note": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: ValidateRequired(),
+			},
+			"creation_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCreateIncidentNote(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	incidentNumber := d.Get("incident_number").(string)
+	newNote := &incidentNote{Note: d.Get("note").(string)}
+
+	var note incidentNote
+	if err := m.(*Meta).request(ctx, http.MethodPost, incidentNotesPath(incidentNumber), newNote, &note); err != nil {
+		return apiErrorDiags(err)
+	}
+	d.SetId(note.UniqueID)
+	return resourceReadIncidentNote(ctx, d, m)
+}
+
+func resourceUpdateIncidentNote(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	incidentNumber := d.Get("incident_number").(string)
+	newNote := &incidentNote{Note: d.Get("note").(string)}
+
+	if err := m.(*Meta).request(ctx, http.MethodPut, incidentNotePath(incidentNumber, d.Id()), newNote, nil); err != nil {
+		return apiErrorDiags(err)
+	}
+	return resourceReadIncidentNote(ctx, d, m)
+}
+
+func resourceDeleteIncidentNote(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	incidentNumber := d.Get("incident_number").(string)
+	if err := m.(*Meta).request(ctx, http.MethodDelete, incidentNotePath(incidentNumber, d.Id()), nil, nil); err != nil {
+		return handleDeleteError(d, err)
+	}
+	return nil
+}
+
+func resourceReadIncidentNote(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	incidentNumber := d.Get("incident_number").(string)
+	var note incidentNote
+	if err := m.(*Meta).request(ctx, http.MethodGet, incidentNotePath(incidentNumber, d.Id()), nil, &note); err != nil {
+		return handleReadError(d, err)
+	}
+	d.Set("note", note.Note)
+	d.Set("creation_date", note.CreationDate)
+	return nil
+}
+
+func resourceIncidentNoteImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("unexpected format of id (%q), expected <incident_number>/<note_id>", d.Id())
+	} else if !incidentNumberPattern.MatchString(parts[0]) {
+		return nil, fmt.Errorf("invalid incident_number (%q)", parts[0])
+	} else if !IsValidUUID(parts[1]) {
+		return nil, fmt.Errorf("invalid note_id (%q)", parts[1])
+	}
+	d.Set("incident_number", parts[0])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
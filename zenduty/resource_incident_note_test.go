@@ -0,0 +1,52 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIncidentNote_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_incident_note"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIncidentNoteConfig(name, username, "Runbook: restart the primary"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_incident_note.test", &id),
+					resource.TestCheckResourceAttrPair("zenduty_incident_note.test", "incident_number", "zenduty_incidents.test", "id"),
+					resource.TestCheckResourceAttr("zenduty_incident_note.test", "note", "Runbook: restart the primary"),
+					resource.TestCheckResourceAttrSet("zenduty_incident_note.test", "creation_date"),
+				),
+			},
+			{
+				Config: testAccIncidentNoteConfig(name, username, "Runbook: fail over to the replica"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_incident_note.test", &id),
+					resource.TestCheckResourceAttr("zenduty_incident_note.test", "note", "Runbook: fail over to the replica"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_incident_note.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_incident_note.test", "incident_number"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccIncidentNoteConfig(name, username, note string) string {
+	return testAccIncidentsConfig(name, username, incidentTriggered) + fmt.Sprintf(`
+resource "zenduty_incident_note" "test" {
+  incident_number = zenduty_incidents.test.id
+  note            = %q
+}
+`, note)
+}
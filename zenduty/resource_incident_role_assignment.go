@@ -0,0 +1,124 @@
+package zenduty
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// incidentRoleAssignment gives an incident role of the team of the incident
+// to a user. The SDK does not cover role assignments, so they are sent with
+// Meta.request.
+type incidentRoleAssignment struct {
+	UniqueID string `json:"unique_id,omitempty"`
+	Role     string `json:"role"`
+	User     string `json:"user"`
+}
+
+func incidentRolesPath(incidentNumber string) string {
+	return fmt.Sprintf("/api/incidents/%s/roles/", incidentNumber)
+}
+
+func incidentRolePath(incidentNumber, id string) string {
+	return fmt.Sprintf("/api/incidents/%s/roles/%s/", incidentNumber, id)
+}
+
+func resourceIncidentRoleAssignment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCreateIncidentRoleAssignment,
+		ReadContext:   resourceReadIncidentRoleAssignment,
+		UpdateContext: resourceUpdateIncidentRoleAssignment,
+		DeleteContext: resourceDeleteIncidentRoleAssignment,
+		Timeouts:      resourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			State: resourceIncidentRoleAssignmentImporter,
+		},
+		Schema: map[string]*schema.Schema{
+			"incident_number": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(incidentNumberPattern, "must be an incident number"),
+			},
+			"role": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: ValidateUUID(),
+			},
+			"user": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: ValidateRequired(),
+			},
+		},
+	}
+}
+
+func resourceCreateIncidentRoleAssignment(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	incidentNumber := d.Get("incident_number").(string)
+	newAssignment := &incidentRoleAssignment{
+		Role: d.Get("role").(string),
+		User: d.Get("user").(string),
+	}
+
+	var assignment incidentRoleAssignment
+	if err := m.(*Meta).request(ctx, http.MethodPost, incidentRolesPath(incidentNumber), newAssignment, &assignment); err != nil {
+		return apiErrorDiags(err)
+	}
+	d.SetId(assignment.UniqueID)
+	return resourceReadIncidentRoleAssignment(ctx, d, m)
+}
+
+// resourceUpdateIncidentRoleAssignment hands the role over to another user
+// without removing it from the incident in between.
+func resourceUpdateIncidentRoleAssignment(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	incidentNumber := d.Get("incident_number").(string)
+	newAssignment := &incidentRoleAssignment{
+		Role: d.Get("role").(string),
+		User: d.Get("user").(string),
+	}
+
+	if err := m.(*Meta).request(ctx, http.MethodPut, incidentRolePath(incidentNumber, d.Id()), newAssignment, nil); err != nil {
+		return apiErrorDiags(err)
+	}
+	return resourceReadIncidentRoleAssignment(ctx, d, m)
+}
+
+func resourceDeleteIncidentRoleAssignment(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	incidentNumber := d.Get("incident_number").(string)
+	if err := m.(*Meta).request(ctx, http.MethodDelete, incidentRolePath(incidentNumber, d.Id()), nil, nil); err != nil {
+		return handleDeleteError(d, err)
+	}
+	return nil
+}
+
+func resourceReadIncidentRoleAssignment(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	incidentNumber := d.Get("incident_number").(string)
+	var assignment incidentRoleAssignment
+	if err := m.(*Meta).request(ctx, http.MethodGet, incidentRolePath(incidentNumber, d.Id()), nil, &assignment); err != nil {
+		return handleReadError(d, err)
+	}
+	d.Set("role", assignment.Role)
+	d.Set("user", assignment.User)
+	return nil
+}
+
+func resourceIncidentRoleAssignmentImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("unexpected format of id (%q), expected <incident_number>/<assignment_id>", d.Id())
+	} else if !incidentNumberPattern.MatchString(parts[0]) {
+		return nil, fmt.Errorf("invalid incident_number (%q)", parts[0])
+	} else if !IsValidUUID(parts[1]) {
+		return nil, fmt.Errorf("invalid assignment_id (%q)", parts[1])
+	}
+	d.Set("incident_number", parts[0])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
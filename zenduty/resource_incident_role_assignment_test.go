@@ -0,0 +1,62 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIncidentRoleAssignment_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	other := testAccExisting(t, "ZENDUTY_TEST_OTHER_USERNAME", fakeUsername())
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_incident_role_assignment"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIncidentRoleAssignmentConfig(name, username, username),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_incident_role_assignment.test", &id),
+					resource.TestCheckResourceAttrPair("zenduty_incident_role_assignment.test", "role", "zenduty_roles.test", "id"),
+					resource.TestCheckResourceAttr("zenduty_incident_role_assignment.test", "user", username),
+				),
+			},
+			{
+				Config: testAccIncidentRoleAssignmentConfig(name, username, other),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_incident_role_assignment.test", &id),
+					resource.TestCheckResourceAttr("zenduty_incident_role_assignment.test", "user", other),
+				),
+			},
+			{
+				ResourceName:      "zenduty_incident_role_assignment.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_incident_role_assignment.test", "incident_number"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccIncidentRoleAssignmentConfig opens an incident paging username and
+// gives an incident role of its team to assignee.
+func testAccIncidentRoleAssignmentConfig(name, username, assignee string) string {
+	return testAccIncidentsConfig(name, username, incidentTriggered) + fmt.Sprintf(`
+resource "zenduty_roles" "test" {
+  team        = zenduty_teams.test.id
+  title       = %q
+  description = "Created by acceptance tests"
+  rank        = 1
+}
+
+resource "zenduty_incident_role_assignment" "test" {
+  incident_number = zenduty_incidents.test.id
+  role            = zenduty_roles.test.id
+  user            = %q
+}
+`, name, assignee)
+}
@@ -2,13 +2,21 @@ package zenduty
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
-
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Incident statuses. An incident only moves forward through them.
+const (
+	incidentTriggered    = 1
+	incidentAcknowledged = 2
+	incidentResolved     = 3
 )
 
 func resourceIncidents() *schema.Resource {
@@ -17,40 +25,86 @@ func resourceIncidents() *schema.Resource {
 		UpdateContext: resourceIncidentUpdate,
 		DeleteContext: resourceIncidentDelete,
 		ReadContext:   resourceIncidentRead,
+		Timeouts:      resourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			State: resourceIncidentImporter,
+		},
+		CustomizeDiff: resourceIncidentCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"service": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"escalation_policy": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"user": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"title": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"summary": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"status": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(incidentTriggered, incidentResolved),
+			},
+			"incident_number": {
 				Type:     schema.TypeInt,
-				Optional: true,
+				Computed: true,
+			},
+			"unique_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"assigned_to": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"urgency": {
+				Type:     schema.TypeInt,
+				Computed: true,
 			},
 		},
 	}
 }
 
-func resourceIncidentsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+// resourceIncidentCustomizeDiff fails the plan when status moves backwards,
+// for example when the incident was resolved in the UI while the
+// configuration still has it acknowledged. An incident cannot be reopened,
+// and creating a new one in its place would page the responders again.
+func resourceIncidentCustomizeDiff(Ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("status") || !valueKnown(d, "status") {
+		return nil
+	}
+	old, new := d.GetChange("status")
+	if new.(int) < old.(int) {
+		return cty.GetAttrPath("status").NewErrorf("incident #%s has status %d in Zenduty and cannot move back to %d; set status to %d, or leave it unset", d.Id(), old.(int), new.(int), old.(int))
+	}
+	return nil
+}
 
+func resourceIncidentsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	newIncident := &client.Incident{}
-	var diags diag.Diagnostics
+
 	summary := d.Get("summary").(string)
 	if summary != "" {
 		newIncident.Summary = summary
@@ -70,38 +124,85 @@ func resourceIncidentsCreate(ctx context.Context, d *schema.ResourceData, m inte
 
 	incident, err := apiclient.Incidents.CreateIncident(newIncident)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(strconv.Itoa(incident.IncidentNumber))
-	return diags
+
+	if status := d.Get("status").(int); status > incidentTriggered {
+		if err := setIncidentStatus(apiclient, d.Id(), status); err != nil {
+			return apiErrorDiags(err)
+		}
+	}
+	return resourceIncidentRead(ctx, d, m)
 }
 
 func resourceIncidentUpdate(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	status := d.Get("status").(int)
-	if status != 0 {
-		apiclient, _ := m.(*Config).Client()
-
-		id := d.Id()
-		newStatus := &client.IncidentStatus{}
-		_, err := apiclient.Incidents.UpdateIncident(id, newStatus)
+	if d.HasChange("status") {
+		apiclient, err := m.(*Meta).Client(Ctx)
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		old, new := d.GetChange("status")
+		if new.(int) < old.(int) {
+			return attributeDiagf(cty.GetAttrPath("status"), "incident #%s cannot move back from status %d to %d", d.Id(), old.(int), new.(int))
+		}
+		if err := setIncidentStatus(apiclient, d.Id(), new.(int)); err != nil {
+			return apiErrorDiags(err)
+		}
 	}
-	var diags diag.Diagnostics
-	return diags
+	return resourceIncidentRead(Ctx, d, m)
 }
 
+// resourceIncidentDelete resolves the incident. Incidents cannot be deleted,
+// so one that is already resolved is only removed from the state.
 func resourceIncidentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-
-	var diags diag.Diagnostics
-
-	return diags
+	if d.Get("status").(int) == incidentResolved {
+		return nil
+	}
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setIncidentStatus(apiclient, d.Id(), incidentResolved); err != nil {
+		return handleDeleteError(d, err)
+	}
+	return nil
 }
 
 func resourceIncidentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	incident, err := apiclient.Incidents.GetIncidentByNumber(d.Id())
+	if err != nil {
+		return handleReadError(d, err)
+	}
+	d.Set("service", incident.Service)
+	d.Set("escalation_policy", incident.EscalationPolicy)
+	d.Set("title", incident.Title)
+	d.Set("summary", incident.Summary)
+	d.Set("status", incident.Status)
+	d.Set("incident_number", incident.IncidentNumber)
+	d.Set("unique_id", incident.UniqueID)
+	d.Set("assigned_to", incident.AssignedTo)
+	d.Set("urgency", incident.Urgency)
+	// The API only returns who the incident is assigned to now. user is the
+	// first assignee, so it is only taken from there on import.
+	if d.Get("user").(string) == "" {
+		d.Set("user", incident.AssignedTo)
+	}
+	return nil
+}
 
-	var diags diag.Diagnostics
+func setIncidentStatus(apiclient *client.Client, incidentNumber string, status int) error {
+	_, err := apiclient.Incidents.UpdateIncident(incidentNumber, &client.IncidentStatus{Status: status})
+	return err
+}
 
-	return diags
+func resourceIncidentImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := strconv.Atoi(d.Id()); err != nil {
+		return nil, fmt.Errorf("invalid incident number (%q), expected the number of the incident", d.Id())
+	}
+	return []*schema.ResourceData{d}, nil
 }
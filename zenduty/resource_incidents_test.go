@@ -0,0 +1,148 @@
+package zenduty
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccIncidents_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckIncidentsResolved,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIncidentsConfig(name, username, incidentTriggered),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_incidents.test", &id),
+					resource.TestCheckResourceAttr("zenduty_incidents.test", "title", name),
+					resource.TestCheckResourceAttr("zenduty_incidents.test", "status", "1"),
+					resource.TestCheckResourceAttrSet("zenduty_incidents.test", "incident_number"),
+					resource.TestCheckResourceAttrSet("zenduty_incidents.test", "urgency"),
+				),
+			},
+			{
+				Config: testAccIncidentsConfig(name, username, incidentAcknowledged),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_incidents.test", &id),
+					resource.TestCheckResourceAttr("zenduty_incidents.test", "status", "2"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_incidents.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// user is the first assignee and is only read back from the
+				// current one.
+				ImportStateVerifyIgnore: []string{"user"},
+			},
+		},
+	})
+}
+
+// TestAccIncidents_resolvedOutside checks that an incident resolved outside
+// of Terraform fails the plan while the configuration still has it
+// acknowledged, since an incident cannot be reopened, and that it is kept
+// once the configuration accepts the new status.
+func TestAccIncidents_resolvedOutside(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckIncidentsResolved,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIncidentsConfig(name, username, incidentAcknowledged),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_incidents.test", &id),
+					testAccResolveIncident("zenduty_incidents.test"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      testAccIncidentsConfig(name, username, incidentAcknowledged),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`has status 3 in Zenduty and cannot move back to 2`),
+			},
+			{
+				Config: testAccIncidentsConfig(name, username, incidentResolved),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_incidents.test", &id),
+					resource.TestCheckResourceAttr("zenduty_incidents.test", "status", "3"),
+				),
+			},
+		},
+	})
+}
+
+// testAccResolveIncident resolves the incident of resourceName through the
+// API, the way a responder would in the UI.
+func testAccResolveIncident(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+		meta, err := testAccMeta()
+		if err != nil {
+			return err
+		}
+		apiclient, err := meta.Client(context.Background())
+		if err != nil {
+			return err
+		}
+		return setIncidentStatus(apiclient, rs.Primary.ID, incidentResolved)
+	}
+}
+
+// testAccCheckIncidentsResolved checks that destroyed incidents were
+// resolved. Incidents cannot be deleted.
+func testAccCheckIncidentsResolved(s *terraform.State) error {
+	meta, err := testAccMeta()
+	if err != nil {
+		return err
+	}
+	apiclient, err := meta.Client(context.Background())
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "zenduty_incidents" {
+			continue
+		}
+		incident, err := apiclient.Incidents.GetIncidentByNumber(rs.Primary.ID)
+		if isNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if incident.Status != incidentResolved {
+			return fmt.Errorf("incident #%s was not resolved, status is %d", rs.Primary.ID, incident.Status)
+		}
+	}
+	return nil
+}
+
+func testAccIncidentsConfig(name, username string, status int) string {
+	return testAccServicesConfig(name, username, "Created by acceptance tests") + fmt.Sprintf(`
+resource "zenduty_incidents" "test" {
+  service           = zenduty_services.test.id
+  escalation_policy = zenduty_esp.test.id
+  user              = %q
+  title             = %q
+  summary           = "Created by acceptance tests"
+  status            = %d
+}
+`, username, name, status)
+}
@@ -16,7 +16,8 @@ func resourceInvite() *schema.Resource {
 		CreateContext: resourceInviteCreate,
 		UpdateContext: resourceInviteUpdate,
 		DeleteContext: resourceInviteDelete,
-		ReadContext:   wrapReadWith404(resourceInviteRead),
+		ReadContext:   resourceInviteRead,
+		Timeouts:      resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"team": {
 				Type:     schema.TypeString,
@@ -51,7 +52,10 @@ func resourceInvite() *schema.Resource {
 }
 
 func resourceInviteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	newinvite := &client.Invite{}
 	if v, ok := d.GetOk("team"); ok {
@@ -76,9 +80,9 @@ func resourceInviteCreate(ctx context.Context, d *schema.ResourceData, m interfa
 		}
 
 	}
-	_, err := apiclient.Invite.CreateInvite(newinvite)
+	_, err = apiclient.Invite.CreateInvite(newinvite)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(time.Now().String())
 
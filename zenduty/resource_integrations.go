@@ -2,12 +2,12 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -19,7 +19,8 @@ func resourceIntegrations() *schema.Resource {
 		CreateContext: resourceIntegrationCreate,
 		UpdateContext: resourceIntegrationUpdate,
 		DeleteContext: resourceIntegrationDelete,
-		ReadContext:   wrapReadWith404(resourceIntegrationRead),
+		ReadContext:   resourceIntegrationRead,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourceIntegrationImporter,
 		},
@@ -77,7 +78,10 @@ func resourceIntegrations() *schema.Resource {
 }
 
 func resourceIntegrationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	newIntegration := &client.IntegrationCreate{}
 	var diags diag.Diagnostics
@@ -106,7 +110,7 @@ func resourceIntegrationCreate(ctx context.Context, d *schema.ResourceData, m in
 
 	integration, err := apiclient.Integrations.CreateIntegration(teamID, serviceID, newIntegration)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(integration.UniqueID)
 	// added integration_key in response output
@@ -118,7 +122,10 @@ func resourceIntegrationCreate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceIntegrationUpdate(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	id := d.Id()
 
 	newIntegration := &client.IntegrationCreate{}
@@ -148,7 +155,7 @@ func resourceIntegrationUpdate(Ctx context.Context, d *schema.ResourceData, m in
 
 	integration, err := apiclient.Integrations.UpdateIntegration(teamID, serviceID, id, newIntegration)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.Set("integration_key", integration.IntegrationKey)
 	d.Set("is_enabled", integration.IsEnabled)
@@ -159,21 +166,24 @@ func resourceIntegrationUpdate(Ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceIntegrationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	id := d.Id()
 	teamID := d.Get("team_id").(string)
 	serviceID := d.Get("service_id").(string)
 	var diags diag.Diagnostics
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	if serviceID == "" {
-		return diag.FromErr(errors.New("service_id is required"))
+		return attributeDiag(cty.GetAttrPath("service_id"), "service_id is required")
 	}
-	err := apiclient.Integrations.DeleteIntegration(teamID, serviceID, id)
+	err = apiclient.Integrations.DeleteIntegration(teamID, serviceID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 
 	return diags
@@ -185,11 +195,14 @@ func resourceIntegrationRead(ctx context.Context, d *schema.ResourceData, m inte
 	id := d.Id()
 	teamID := d.Get("team_id").(string)
 	serviceID := d.Get("service_id").(string)
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	integration, err := apiclient.Integrations.GetIntegrationByID(teamID, serviceID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.Set("name", integration.Name)
 	d.Set("application", integration.Application)
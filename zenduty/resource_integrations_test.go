@@ -0,0 +1,66 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIntegrations_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	application := testAccApplication(t)
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_integrations"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIntegrationsConfig(name, username, application, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_integrations.test", &id),
+					resource.TestCheckResourceAttr("zenduty_integrations.test", "name", name),
+					resource.TestCheckResourceAttrSet("zenduty_integrations.test", "integration_key"),
+					resource.TestCheckResourceAttrSet("zenduty_integrations.test", "webhook_url"),
+				),
+			},
+			{
+				Config: testAccIntegrationsConfig(name, username, application, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_integrations.test", &id),
+					resource.TestCheckResourceAttr("zenduty_integrations.test", "default_urgency", "0"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_integrations.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_integrations.test", "team_id", "service_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccApplication returns the ID of the integration application used by
+// tests, taken from ZENDUTY_TEST_APPLICATION_ID.
+func testAccApplication(t *testing.T) string {
+	return testAccExisting(t, "ZENDUTY_TEST_APPLICATION_ID", uuid.NewString())
+}
+
+// testAccIntegrationsConfig adds an integration to the service of
+// testAccServicesConfig.
+func testAccIntegrationsConfig(name, username, application string, urgency int) string {
+	return testAccServicesConfig(name, username, "Created by acceptance tests") + fmt.Sprintf(`
+resource "zenduty_integrations" "test" {
+  name            = %q
+  summary         = "Created by acceptance tests"
+  application     = %q
+  team_id         = zenduty_teams.test.id
+  service_id      = zenduty_services.test.id
+  default_urgency = %d
+}
+`, name, application, urgency)
+}
@@ -0,0 +1,51 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// zenduty_invite cannot be read back or imported and invitations are not
+// revoked on destroy, so the test only checks that the invitation is sent
+// and that changing it does not send a new one.
+func TestAccInvite_basic(t *testing.T) {
+	name := testAccName()
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInviteConfig(name, "Test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_invite.test", &id),
+					resource.TestCheckResourceAttr("zenduty_invite.test", "email_accounts.#", "1"),
+				),
+			},
+			{
+				Config: testAccInviteConfig(name, "Updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_invite.test", &id),
+					resource.TestCheckResourceAttr("zenduty_invite.test", "email_accounts.0.first_name", "Updated"),
+				),
+			},
+		},
+	})
+}
+
+func testAccInviteConfig(name, firstName string) string {
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_invite" "test" {
+  team = zenduty_teams.test.id
+
+  email_accounts {
+    email      = "%s@example.com"
+    first_name = %q
+    last_name  = "User"
+    role       = 3
+  }
+}
+`, name, firstName)
+}
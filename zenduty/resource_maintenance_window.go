@@ -2,12 +2,12 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -17,7 +17,9 @@ func resourceMaintenanceWindow() *schema.Resource {
 		CreateContext: resourceCreateManintenances,
 		UpdateContext: resourceUpdateManintenances,
 		DeleteContext: resourceDeleteManintenances,
-		ReadContext:   wrapReadWith404(resourceReadManintenances),
+		ReadContext:   resourceReadManintenances,
+		CustomizeDiff: resourceMaintenanceWindowCustomizeDiff,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourceMaintenanceImporter,
 		},
@@ -32,14 +34,8 @@ func resourceMaintenanceWindow() *schema.Resource {
 				Required:         true,
 				ValidateDiagFunc: ValidateUUID(),
 			},
-			"start_time": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"end_time": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
+			"start_time": timestampSchema(true, "timezone"),
+			"end_time":   timestampSchema(true, "timezone"),
 			"timezone": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -48,10 +44,7 @@ func resourceMaintenanceWindow() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
-			"repeat_until": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
+			"repeat_until": timestampSchema(false, "timezone"),
 			"services": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -64,94 +57,66 @@ func resourceMaintenanceWindow() *schema.Resource {
 	}
 }
 
+func resourceMaintenanceWindowCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	return validateLocalTimes(d, "timezone", "start_time", "end_time", "repeat_until")
+}
+
 func ValidateMaintenanceWindow(Ctx context.Context, d *schema.ResourceData, m interface{}) (*client.MaintenanceWindow, diag.Diagnostics) {
 	newManintence := &client.MaintenanceWindow{}
 	services := d.Get("services").([]interface{})
 
 	if v, ok := d.GetOk("name"); ok {
 		if v.(string) == "" {
-			return nil, diag.FromErr(errors.New("name must not be empty"))
+			return nil, attributeDiag(cty.GetAttrPath("name"), "name must not be empty")
 		}
 		newManintence.Name = v.(string)
 	}
 	if v, ok := d.GetOk("timezone"); ok {
 		if v.(string) == "" {
-			return nil, diag.FromErr(errors.New("timezone must not be empty"))
+			return nil, attributeDiag(cty.GetAttrPath("timezone"), "timezone must not be empty")
 		}
 		newManintence.TimeZone = v.(string)
 	}
 
-	if v, ok := d.GetOk("start_time"); ok {
-		if !validateDate(v.(string)) {
-			return nil, diag.FromErr(errors.New("start_time is invalid"))
-		}
-
-		newManintence.StartTime = v.(string)
-
-		loc, zoneErr := time.LoadLocation(newManintence.TimeZone)
-		if zoneErr != nil {
-			return nil, diag.FromErr(errors.New(zoneErr.Error()))
-		}
-		parsedTime, err := time.ParseInLocation("2006-01-02 15:04", v.(string), loc)
-		if err != nil {
-			return nil, diag.FromErr(errors.New(err.Error()))
-		}
-		newManintence.StartTime = parsedTime.In(time.UTC).Format(time.RFC3339)
-
+	loc, zoneErr := time.LoadLocation(newManintence.TimeZone)
+	if zoneErr != nil {
+		return nil, attributeDiag(cty.GetAttrPath("timezone"), zoneErr.Error())
 	}
-	if v, ok := d.GetOk("end_time"); ok {
-		if !validateDate(v.(string)) {
-			return nil, diag.FromErr(errors.New("end_time is invalid"))
-		}
-		newManintence.EndTime = v.(string)
-
-		loc, zoneErr := time.LoadLocation(newManintence.TimeZone)
-		if zoneErr != nil {
-			return nil, diag.FromErr(errors.New(zoneErr.Error()))
-		}
-		parsedTime, err := time.ParseInLocation("2006-01-02 15:04", v.(string), loc)
-		if err != nil {
-			return nil, diag.FromErr(errors.New(err.Error()))
-		}
-
-		newManintence.EndTime = parsedTime.In(time.UTC).Format(time.RFC3339)
+	var diags, timestampDiags diag.Diagnostics
+	newManintence.StartTime, timestampDiags = toAPITimestamp(d.Get("start_time").(string), loc, cty.GetAttrPath("start_time"))
+	diags = append(diags, timestampDiags...)
+	newManintence.EndTime, timestampDiags = toAPITimestamp(d.Get("end_time").(string), loc, cty.GetAttrPath("end_time"))
+	diags = append(diags, timestampDiags...)
+	if diags.HasError() {
+		return nil, diags
 	}
 
 	if v, ok := d.GetOk("repeat_interval"); ok {
 		if v.(int) <= 0 {
-			return nil, diag.FromErr(errors.New("repeat_interval must be greater than 0"))
+			return nil, attributeDiag(cty.GetAttrPath("repeat_interval"), "repeat_interval must be greater than 0")
 		}
 		newManintence.RepeatInterval = v.(int)
 	}
 	if v, ok := d.GetOk("repeat_until"); ok {
 		if v.(string) == "" {
-			return nil, diag.FromErr(errors.New("repeat_until must not be empty"))
+			return nil, attributeDiag(cty.GetAttrPath("repeat_until"), "repeat_until must not be empty")
 		}
-		if !validateDate(v.(string)) {
-			return nil, diag.FromErr(errors.New("repeat_until is invalid"))
+		newManintence.RepeatUntil, timestampDiags = toAPITimestamp(v.(string), loc, cty.GetAttrPath("repeat_until"))
+		diags = append(diags, timestampDiags...)
+		if diags.HasError() {
+			return nil, diags
 		}
-
-		loc, zoneErr := time.LoadLocation(newManintence.TimeZone)
-		if zoneErr != nil {
-			return nil, diag.FromErr(errors.New(zoneErr.Error()))
-		}
-
-		parsedTime, err := time.ParseInLocation("2006-01-02 15:04", v.(string), loc)
-		if err != nil {
-			return nil, diag.FromErr(errors.New(err.Error()))
-		}
-		newManintence.RepeatUntil = parsedTime.In(time.UTC).Format(time.RFC3339)
 	}
-	for _, service := range services {
+	for i, service := range services {
 		if service.(string) == "" {
-			return nil, diag.FromErr(errors.New("services must not be empty"))
+			return nil, attributeDiag(cty.GetAttrPath("services").IndexInt(i), "services must not be empty")
 		}
 		if !IsValidUUID(service.(string)) {
-			return nil, diag.FromErr(errors.New("services must be a valid UUID"))
+			return nil, attributeDiag(cty.GetAttrPath("services").IndexInt(i), "services must be a valid UUID")
 		}
 		newManintence.Services = append(newManintence.Services, client.ServiceMaintenance{Service: service.(string)})
 	}
-	return newManintence, nil
+	return newManintence, diags
 }
 
 // if v, ok := d.GetOk("services"); ok {
@@ -171,63 +136,72 @@ func resourceCreateManintenances(ctx context.Context, d *schema.ResourceData, m
 	var teamID string
 	if v, ok := d.GetOk("team_id"); ok {
 		if !IsValidUUID(v.(string)) {
-			return diag.FromErr(errors.New("team_id must be a valid UUID"))
+			return attributeDiag(cty.GetAttrPath("team_id"), "team_id must be a valid UUID")
 
 		}
 		teamID = v.(string)
 	}
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	newManintence, diags := ValidateMaintenanceWindow(ctx, d, m)
-	if diags != nil {
+	if diags.HasError() {
 		return diags
 	}
 	maintenance, err := apiclient.MaintenanceWindow.CreateMaintenanceWindow(teamID, newManintence)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(maintenance.UniqueID)
-	return nil
+	return diags
 }
 
 func resourceUpdateManintenances(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var teamID string
 	if v, ok := d.GetOk("team_id"); ok {
 		if !IsValidUUID(v.(string)) {
-			return diag.FromErr(errors.New("team_id must be a valid UUID"))
+			return attributeDiag(cty.GetAttrPath("team_id"), "team_id must be a valid UUID")
 		}
 		teamID = v.(string)
 	}
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	newManintence, diags := ValidateMaintenanceWindow(ctx, d, m)
-	if diags != nil {
+	if diags.HasError() {
 		return diags
 	}
 	maintenance, err := apiclient.MaintenanceWindow.UpdateMaintenanceWindow(teamID, d.Id(), newManintence)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(maintenance.UniqueID)
-	return nil
+	return diags
 }
 
 func resourceReadManintenances(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var teamID string
 	if v, ok := d.GetOk("team_id"); ok {
 		if !IsValidUUID(v.(string)) {
-			return diag.FromErr(errors.New("team_id must be a valid UUID"))
+			return attributeDiag(cty.GetAttrPath("team_id"), "team_id must be a valid UUID")
 		}
 		teamID = v.(string)
 	}
-	apiclient, _ := m.(*Config).Client()
-	maintenance, err := apiclient.MaintenanceWindow.GetMaintenanceWindowByID(teamID, d.Id())
+	apiclient, err := m.(*Meta).Client(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	maintenance, err := apiclient.MaintenanceWindow.GetMaintenanceWindowByID(teamID, d.Id())
+	if err != nil {
+		return handleReadError(d, err)
+	}
 	d.Set("name", maintenance.Name)
 	d.Set("repeat_interval", maintenance.RepeatInterval)
-	startTime, timeErr := createMaintenanceTimeFormat(maintenance.StartTime, maintenance.TimeZone)
-	endTime, endTimeErr := createMaintenanceTimeFormat(maintenance.EndTime, maintenance.TimeZone)
-	RepeatUntil, RepeatUntilErr := createMaintenanceTimeFormat(maintenance.RepeatUntil, maintenance.TimeZone)
+	startTime, timeErr := fromAPITimestamp(maintenance.StartTime, maintenance.TimeZone)
+	endTime, endTimeErr := fromAPITimestamp(maintenance.EndTime, maintenance.TimeZone)
+	RepeatUntil, RepeatUntilErr := fromAPITimestamp(maintenance.RepeatUntil, maintenance.TimeZone)
 	if RepeatUntilErr == nil {
 		d.Set("repeat_until", RepeatUntil)
 	}
@@ -250,34 +224,22 @@ func flattenServices(services []client.ServiceMaintenance) []interface{} {
 	return servicesList
 }
 
-func createMaintenanceTimeFormat(timestamp, Zone string) (string, error) {
-	RFC3339local := "2006-01-02T15:04:05Z"
-	loc, zoneErr := time.LoadLocation(Zone)
-	if zoneErr != nil {
-		return "", zoneErr
-	}
-	t, parseErr := time.ParseInLocation(RFC3339local, timestamp, time.UTC)
-	if parseErr != nil {
-		return "", parseErr
-	}
-
-	return t.In(loc).Format("2006-01-02 15:04"), nil
-
-}
-
 func resourceDeleteManintenances(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var teamID string
 	if v, ok := d.GetOk("team_id"); ok {
 		if !IsValidUUID(v.(string)) {
-			return diag.FromErr(errors.New("team_id must be a valid UUID"))
+			return attributeDiag(cty.GetAttrPath("team_id"), "team_id must be a valid UUID")
 		}
 		teamID = v.(string)
 	}
-	apiclient, _ := m.(*Config).Client()
-	err := apiclient.MaintenanceWindow.DeleteMaintenanceWindow(teamID, d.Id())
+	apiclient, err := m.(*Meta).Client(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	err = apiclient.MaintenanceWindow.DeleteMaintenanceWindow(teamID, d.Id())
+	if err != nil {
+		return handleDeleteError(d, err)
+	}
 	return nil
 }
 
@@ -0,0 +1,57 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMaintenanceWindow_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_maintenance_window"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMaintenanceWindowConfig(name, username, "2030-01-01 12:00"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_maintenance_window.test", &id),
+					resource.TestCheckResourceAttr("zenduty_maintenance_window.test", "start_time", "2030-01-01 10:00"),
+					resource.TestCheckResourceAttrPair("zenduty_maintenance_window.test", "services.0", "zenduty_services.test", "id"),
+				),
+			},
+			{
+				Config: testAccMaintenanceWindowConfig(name, username, "2030-01-01 14:30"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_maintenance_window.test", &id),
+					resource.TestCheckResourceAttr("zenduty_maintenance_window.test", "end_time", "2030-01-01 14:30"),
+					resource.TestCheckResourceAttr("zenduty_maintenance_window.test", "name", name),
+					resource.TestCheckResourceAttr("zenduty_maintenance_window.test", "timezone", "Asia/Kolkata"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_maintenance_window.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_maintenance_window.test", "team_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMaintenanceWindowConfig(name, username, endTime string) string {
+	return testAccServicesConfig(name, username, "Created by acceptance tests") + fmt.Sprintf(`
+resource "zenduty_maintenance_window" "test" {
+  name       = %q
+  team_id    = zenduty_teams.test.id
+  timezone   = "Asia/Kolkata"
+  start_time = "2030-01-01 10:00"
+  end_time   = %q
+  services   = [zenduty_services.test.id]
+}
+`, name, endTime)
+}
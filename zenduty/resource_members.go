@@ -15,9 +15,10 @@ import (
 func resourceMembers() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceMemberCreate,
-		ReadContext:   wrapReadWith404(resourceMemberRead),
+		ReadContext:   resourceMemberRead,
 		UpdateContext: resourceMemberUpdate,
 		DeleteContext: resourceMemberDelete,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceMemberImporter,
 		},
@@ -41,7 +42,10 @@ func resourceMembers() *schema.Resource {
 }
 
 func resourceMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	newMembers := &client.Member{}
 	role := d.Get("role").(int)
@@ -61,14 +65,17 @@ func resourceMemberCreate(ctx context.Context, d *schema.ResourceData, m interfa
 
 	member, err := apiclient.Members.CreateTeamMember(newMembers.Team, newMembers)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(member.UniqueID)
 	return diags
 }
 
 func resourceMemberUpdate(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	newMembers := &client.Member{}
 	id := d.Id()
@@ -88,36 +95,42 @@ func resourceMemberUpdate(Ctx context.Context, d *schema.ResourceData, m interfa
 	if v, ok := d.GetOk("team"); ok {
 		newMembers.Team = v.(string)
 	}
-	_, err := apiclient.Members.UpdateTeamMember(newMembers)
+	_, err = apiclient.Members.UpdateTeamMember(newMembers)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	return diags
 
 }
 
 func resourceMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	id := d.Id()
 	team := d.Get("team").(string)
 	var diags diag.Diagnostics
-	err := apiclient.Members.DeleteTeamMember(team, id)
+	err = apiclient.Members.DeleteTeamMember(team, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return diags
 }
 
 func resourceMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	id := d.Id()
 	team := d.Get("team").(string)
 	var diags diag.Diagnostics
 	member, err := apiclient.Members.GetTeamMembersByID(team, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.Set("team", member.Team)
 	d.Set("user", member.User.Username) // Extract username from User object
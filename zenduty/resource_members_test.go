@@ -0,0 +1,52 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMember_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_member"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMemberConfig(name, username, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_member.test", &id),
+					resource.TestCheckResourceAttr("zenduty_member.test", "user", username),
+					resource.TestCheckResourceAttr("zenduty_member.test", "role", "2"),
+				),
+			},
+			{
+				Config: testAccMemberConfig(name, username, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_member.test", &id),
+					resource.TestCheckResourceAttr("zenduty_member.test", "role", "1"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_member.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_member.test", "team"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMemberConfig(name, username string, role int) string {
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_member" "test" {
+  team = zenduty_teams.test.id
+  user = %q
+  role = %d
+}
+`, username, role)
+}
@@ -14,9 +14,10 @@ import (
 func resourceNotificationRules() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceCreateNotificationRule,
-		ReadContext:   wrapReadWith404(resourceReadNotificationRule),
+		ReadContext:   resourceReadNotificationRule,
 		UpdateContext: resourceUpdateNotificationRule,
 		DeleteContext: resourceDeleteNotificationRule,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourceNotificationRuleImporter,
 		},
@@ -44,7 +45,10 @@ func resourceNotificationRules() *schema.Resource {
 }
 
 func resourceCreateNotificationRule(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	newNotificationRule := &client.CreateNotificationRules{}
 	var diags diag.Diagnostics
 	var username string
@@ -62,14 +66,17 @@ func resourceCreateNotificationRule(Ctx context.Context, d *schema.ResourceData,
 	}
 	notificationRule, err := apiclient.NotificationRules.CreateNotificationRules(username, newNotificationRule)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(notificationRule.UniqueID)
 	return diags
 }
 
 func resourceUpdateNotificationRule(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	newNotificationRule := &client.NotificationRules{}
 	var diags diag.Diagnostics
 	var username string
@@ -87,14 +94,17 @@ func resourceUpdateNotificationRule(Ctx context.Context, d *schema.ResourceData,
 	}
 	notificationRule, err := apiclient.NotificationRules.UpdateNotificationRules(username, d.Id(), newNotificationRule)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(notificationRule.UniqueID)
 	return diags
 }
 
 func resourceReadNotificationRule(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	var diags diag.Diagnostics
 	var username string
 	if v, ok := d.GetOk("username"); ok {
@@ -102,7 +112,7 @@ func resourceReadNotificationRule(Ctx context.Context, d *schema.ResourceData, m
 	}
 	notificationRule, err := apiclient.NotificationRules.GetNotificationRulesByID(username, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.Set("contact", notificationRule.Contact)
 	d.Set("delay", notificationRule.StartDelay)
@@ -112,15 +122,18 @@ func resourceReadNotificationRule(Ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceDeleteNotificationRule(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	var diags diag.Diagnostics
 	var username string
 	if v, ok := d.GetOk("username"); ok {
 		username = v.(string)
 	}
-	err := apiclient.NotificationRules.DeleteNotificationRules(username, d.Id())
+	err = apiclient.NotificationRules.DeleteNotificationRules(username, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return diags
 }
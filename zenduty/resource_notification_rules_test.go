@@ -0,0 +1,57 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNotificationRules_basic(t *testing.T) {
+	username := testAccUsername(t)
+	contact := testAccExisting(t, "ZENDUTY_TEST_CONTACT_ID", uuid.NewString())
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_notification_rules"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotificationRulesConfig(username, contact, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_notification_rules.test", &id),
+					resource.TestCheckResourceAttr("zenduty_notification_rules.test", "contact", contact),
+					resource.TestCheckResourceAttr("zenduty_notification_rules.test", "delay", "1"),
+				),
+			},
+			{
+				Config: testAccNotificationRulesConfig(username, contact, 5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_notification_rules.test", &id),
+					resource.TestCheckResourceAttr("zenduty_notification_rules.test", "delay", "5"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_notification_rules.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_notification_rules.test", "username"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccNotificationRulesConfig declares a notification rule on the
+// existing contact method contact of username. A real account needs
+// ZENDUTY_TEST_CONTACT_ID set to one of that user's contact methods.
+func testAccNotificationRulesConfig(username, contact string, delay int) string {
+	return fmt.Sprintf(`
+resource "zenduty_notification_rules" "test" {
+  username = %q
+  contact  = %q
+  urgency  = 1
+  delay    = %d
+}
+`, username, contact, delay)
+}
@@ -2,11 +2,11 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -16,7 +16,8 @@ func resourceOutgoingRules() *schema.Resource {
 		CreateContext: resourceCreateOutgoingRules,
 		UpdateContext: resourceUpdateOutgoingRules,
 		DeleteContext: resourceDeleteOutgoingRules,
-		ReadContext:   wrapReadWith404(resourceReadOutgoingRules),
+		ReadContext:   resourceReadOutgoingRules,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourceOutgoingRulesImporter,
 		},
@@ -59,17 +60,20 @@ func ValidateAncCreateOutgoingRules(Ctx context.Context, d *schema.ResourceData,
 	newAlertRule.Enabled = enabled.(bool)
 
 	if newAlertRule.RuleJSON == "" {
-		return nil, diag.FromErr(errors.New("rule_json is required"))
+		return nil, attributeDiag(cty.GetAttrPath("rule_json"), "rule_json is required")
 	}
 	if !isJSONString(newAlertRule.RuleJSON) {
-		return nil, diag.FromErr(errors.New("rule_json is not valid JSON"))
+		return nil, attributeDiag(cty.GetAttrPath("rule_json"), "rule_json is not valid JSON")
 	}
 
 	return newAlertRule, nil
 }
 
 func resourceCreateOutgoingRules(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	var diags diag.Diagnostics
 	var teamID, serviceID, integrationID string
 
@@ -85,14 +89,17 @@ func resourceCreateOutgoingRules(Ctx context.Context, d *schema.ResourceData, m
 	alertRule, err := apiclient.OutgoingRules.CreateOutgoingRule(teamID, serviceID, integrationID, newRule)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(alertRule.UniqueID)
 	return diags
 }
 
 func resourceUpdateOutgoingRules(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	var diags diag.Diagnostics
 	var teamID, serviceID, integrationID string
 
@@ -105,16 +112,19 @@ func resourceUpdateOutgoingRules(Ctx context.Context, d *schema.ResourceData, m
 		return ruleErr
 	}
 
-	_, err := apiclient.OutgoingRules.UpdateOutgoingRule(teamID, serviceID, integrationID, d.Id(), newRule)
+	_, err = apiclient.OutgoingRules.UpdateOutgoingRule(teamID, serviceID, integrationID, d.Id(), newRule)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	return diags
 }
 
 func resourceReadOutgoingRules(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	var diags diag.Diagnostics
 	var teamID, serviceID, integrationID string
 
@@ -124,7 +134,7 @@ func resourceReadOutgoingRules(Ctx context.Context, d *schema.ResourceData, m in
 
 	rule, err := apiclient.OutgoingRules.GetOutgoingRule(teamID, serviceID, integrationID, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.SetId(rule.UniqueID)
 	d.Set("rule_json", rule.RuleJSON)
@@ -135,12 +145,15 @@ func resourceReadOutgoingRules(Ctx context.Context, d *schema.ResourceData, m in
 
 func resourceDeleteOutgoingRules(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	teamID, serviceID, integrationID, uniqueID := d.Get("team_id").(string), d.Get("service_id").(string), d.Get("integration_id").(string), d.Id()
 
-	err := apiclient.OutgoingRules.DeleteOutgoingRule(teamID, serviceID, integrationID, uniqueID)
+	err = apiclient.OutgoingRules.DeleteOutgoingRule(teamID, serviceID, integrationID, uniqueID)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return diags
 }
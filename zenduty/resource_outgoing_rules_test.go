@@ -0,0 +1,65 @@
+package zenduty
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccOutgoingRules_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	application := testAccApplication(t)
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_outgoing_rules"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOutgoingRulesConfig(name, username, application, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_outgoing_rules.test", &id),
+					resource.TestCheckResourceAttr("zenduty_outgoing_rules.test", "enabled", "true"),
+					resource.TestCheckResourceAttrPair("zenduty_outgoing_rules.test", "service_id", "zenduty_services.test", "id"),
+					resource.TestCheckResourceAttrPair("zenduty_outgoing_rules.test", "integration_id", "zenduty_integrations.test", "id"),
+					resource.TestMatchResourceAttr("zenduty_outgoing_rules.test", "rule_json", regexp.MustCompile(`"value":"resolved"`)),
+				),
+			},
+			{
+				Config: testAccOutgoingRulesConfig(name, username, application, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_outgoing_rules.test", &id),
+					resource.TestCheckResourceAttr("zenduty_outgoing_rules.test", "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_outgoing_rules.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_outgoing_rules.test", "team_id", "service_id", "integration_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccOutgoingRulesConfig(name, username, application string, enabled bool) string {
+	return testAccIntegrationsConfig(name, username, application, 1) + fmt.Sprintf(`
+resource "zenduty_outgoing_rules" "test" {
+  team_id        = zenduty_teams.test.id
+  service_id     = zenduty_services.test.id
+  integration_id = zenduty_integrations.test.id
+  enabled        = %t
+  rule_json = jsonencode({
+    condition = "AND"
+    conditions = [{
+      key      = "payload.status"
+      operator = "is"
+      value    = "resolved"
+    }]
+  })
+}
+`, enabled)
+}
@@ -2,13 +2,13 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -20,7 +20,8 @@ func resourcePostIncidentTasks() *schema.Resource {
 		CreateContext: resourceCreatePostIncidentTasks,
 		UpdateContext: resourceUpdatePostIncidentTasks,
 		DeleteContext: resourceDeletePostIncidentTasks,
-		ReadContext:   wrapReadWith404(resourceReadPostIncidentTasks),
+		ReadContext:   resourceReadPostIncidentTasks,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourcePostIncidentTasksImporter,
 		},
@@ -115,11 +116,14 @@ func CreatePostIncidentTask(Ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceCreatePostIncidentTasks(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 
 	task, createErr := CreatePostIncidentTask(Ctx, d, m)
@@ -130,7 +134,7 @@ func resourceCreatePostIncidentTasks(Ctx context.Context, d *schema.ResourceData
 	var diags diag.Diagnostics
 	incidenttask, err := apiclient.PostIncidentTask.CreatePostIncidentTask(teamID, task)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	d.SetId(incidenttask.UniqueID)
@@ -139,12 +143,15 @@ func resourceCreatePostIncidentTasks(Ctx context.Context, d *schema.ResourceData
 }
 
 func resourceUpdatePostIncidentTasks(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	id := d.Id()
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	task, createErr := CreatePostIncidentTask(Ctx, d, m)
 	if createErr != nil {
@@ -152,41 +159,47 @@ func resourceUpdatePostIncidentTasks(Ctx context.Context, d *schema.ResourceData
 
 	}
 
-	_, err := apiclient.PostIncidentTask.UpdatePostIncidentTaskByID(teamID, id, task)
+	_, err = apiclient.PostIncidentTask.UpdatePostIncidentTaskByID(teamID, id, task)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	return resourceReadPostIncidentTasks(Ctx, d, m)
 }
 
 func resourceDeletePostIncidentTasks(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	id := d.Id()
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	var diags diag.Diagnostics
-	err := apiclient.PostIncidentTask.DeletePostIncidentTaskByID(teamID, id)
+	err = apiclient.PostIncidentTask.DeletePostIncidentTaskByID(teamID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return diags
 }
 
 func resourceReadPostIncidentTasks(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	id := d.Id()
 	if emptyString(teamID) {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	var diags diag.Diagnostics
 	postincidenttask, err := apiclient.PostIncidentTask.GetPostIncidentTaskByID(teamID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 
 	d.Set("title", postincidenttask.Title)
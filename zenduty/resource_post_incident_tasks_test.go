@@ -0,0 +1,53 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostIncidentTasks_basic(t *testing.T) {
+	name := testAccName()
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_post_incident_tasks"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostIncidentTasksConfig(name, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_post_incident_tasks.test", &id),
+					resource.TestCheckResourceAttr("zenduty_post_incident_tasks.test", "due_in_time", "2030-01-01 10:00"),
+				),
+			},
+			{
+				Config: testAccPostIncidentTasksConfig(name, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_post_incident_tasks.test", &id),
+					resource.TestCheckResourceAttr("zenduty_post_incident_tasks.test", "status", "1"),
+					resource.TestCheckResourceAttr("zenduty_post_incident_tasks.test", "title", name),
+				),
+			},
+			{
+				ResourceName:      "zenduty_post_incident_tasks.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_post_incident_tasks.test", "team_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccPostIncidentTasksConfig(name string, status int) string {
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_post_incident_tasks" "test" {
+  team_id     = zenduty_teams.test.id
+  title       = %q
+  description = "Created by acceptance tests"
+  status      = %d
+  due_in_time = "2030-01-01 10:00"
+}
+`, name, status)
+}
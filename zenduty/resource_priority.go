@@ -2,11 +2,11 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -16,7 +16,8 @@ func resourcePriority() *schema.Resource {
 		CreateContext: resourceCreatePriority,
 		UpdateContext: resourceUpdatePriority,
 		DeleteContext: resourceDeletePriority,
-		ReadContext:   wrapReadWith404(resourceReadPriority),
+		ReadContext:   resourceReadPriority,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourcePriorityImporter,
 		},
@@ -51,10 +52,10 @@ func validatePriority(Ctx context.Context, d *schema.ResourceData, m interface{}
 
 	newPriority := &client.Priority{}
 	if !IsValidUUID(team) {
-		return nil, diag.FromErr(errors.New("team_id must be a valid UUID"))
+		return nil, attributeDiag(cty.GetAttrPath("team_id"), "team_id must be a valid UUID")
 	}
 	if color != "" && !checkList(color, []string{"magenta", "red", "volcano", "orange", "gold", "lime", "green", "cyan", "blue", "geekblue", "purple"}) {
-		return nil, diag.FromErr(errors.New("color must be one of the following: magenta, red, volcano, orange, gold, lime, green, cyan, blue, geekblue, purple"))
+		return nil, attributeDiag(cty.GetAttrPath("color"), "color must be one of the following: magenta, red, volcano, orange, gold, lime, green, cyan, blue, geekblue, purple")
 	}
 
 	newPriority.Name = name
@@ -66,14 +67,17 @@ func validatePriority(Ctx context.Context, d *schema.ResourceData, m interface{}
 
 func resourceCreatePriority(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	team := d.Get("team_id").(string)
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	newpriority, validationerr := validatePriority(ctx, d, m)
 	if validationerr != nil {
 		return validationerr
 	}
 	tag, err := apiclient.Priority.CreatePriority(team, newpriority)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(tag.UniqueID)
 	return nil
@@ -81,14 +85,17 @@ func resourceCreatePriority(ctx context.Context, d *schema.ResourceData, m inter
 
 func resourceUpdatePriority(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	team := d.Get("team_id").(string)
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	newpriority, validationerr := validatePriority(ctx, d, m)
 	if validationerr != nil {
 		return validationerr
 	}
 	tag, err := apiclient.Priority.UpdatePriority(team, d.Id(), newpriority)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(tag.UniqueID)
 	return nil
@@ -96,21 +103,27 @@ func resourceUpdatePriority(ctx context.Context, d *schema.ResourceData, m inter
 
 func resourceDeletePriority(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	team := d.Get("team_id").(string)
-	apiclient, _ := m.(*Config).Client()
-
-	err := apiclient.Priority.DeletePriority(team, d.Id())
+	apiclient, err := m.(*Meta).Client(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	err = apiclient.Priority.DeletePriority(team, d.Id())
+	if err != nil {
+		return handleDeleteError(d, err)
+	}
 	return nil
 }
 
 func resourceReadPriority(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	team := d.Get("team_id").(string)
 	tag, err := apiclient.Priority.GetPriorityByID(team, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.SetId(tag.UniqueID)
 	d.Set("name", tag.Name)
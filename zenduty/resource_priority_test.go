@@ -0,0 +1,52 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPriority_basic(t *testing.T) {
+	name := testAccName()
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_priorities"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPriorityConfig(name, "red"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_priorities.test", &id),
+					resource.TestCheckResourceAttr("zenduty_priorities.test", "name", name),
+				),
+			},
+			{
+				Config: testAccPriorityConfig(name, "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_priorities.test", &id),
+					resource.TestCheckResourceAttr("zenduty_priorities.test", "color", "blue"),
+					resource.TestCheckResourceAttr("zenduty_priorities.test", "description", "Created by acceptance tests"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_priorities.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_priorities.test", "team_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccPriorityConfig(name, color string) string {
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_priorities" "test" {
+  team_id     = zenduty_teams.test.id
+  name        = %q
+  description = "Created by acceptance tests"
+  color       = %q
+}
+`, name, color)
+}
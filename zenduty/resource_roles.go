@@ -7,6 +7,7 @@ import (
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,7 +19,8 @@ func resourceRoles() *schema.Resource {
 		CreateContext: resourceRoleCreate,
 		UpdateContext: resourceRoleUpdate,
 		DeleteContext: resourceRoleDelete,
-		ReadContext:   wrapReadWith404(resourceRoleRead),
+		ReadContext:   resourceRoleRead,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourceIncidentRoleImporter,
 		},
@@ -55,7 +57,10 @@ func resourceRoles() *schema.Resource {
 }
 
 func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	newrole := &client.Roles{}
 	var diags diag.Diagnostics
@@ -77,20 +82,23 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface
 			newrole.Rank = 1
 		}
 		if newrole.Rank <= 0 || newrole.Rank > 10 {
-			return diag.Errorf("Rank should be between 1 and 10")
+			return attributeDiag(cty.GetAttrPath("rank"), "Rank should be between 1 and 10")
 		}
 	}
 
 	role, err := apiclient.Roles.CreateRole(newrole.Team, newrole)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(role.UniqueID)
 	return diags
 }
 
 func resourceRoleUpdate(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	newrole := &client.Roles{}
 	var teamID string
@@ -113,38 +121,44 @@ func resourceRoleUpdate(Ctx context.Context, d *schema.ResourceData, m interface
 			newrole.Rank = 1
 		}
 		if newrole.Rank <= 0 || newrole.Rank > 10 {
-			return diag.Errorf("Rank should be between 1 and 10")
+			return attributeDiag(cty.GetAttrPath("rank"), "Rank should be between 1 and 10")
 		}
 	}
-	_, err := apiclient.Roles.UpdateRoles(teamID, newrole)
+	_, err = apiclient.Roles.UpdateRoles(teamID, newrole)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	return diags
 }
 
 func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	id := d.Id()
 	teamID := d.Get("team").(string)
 	var diags diag.Diagnostics
 
-	err := apiclient.Roles.DeleteRole(teamID, id)
+	err = apiclient.Roles.DeleteRole(teamID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return diags
 }
 func resourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	id := d.Id()
 	teamID := d.Get("team").(string)
 	var diags diag.Diagnostics
 	role, err := apiclient.Roles.GetRolesByID(teamID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.Set("title", role.Title)
 	d.Set("description", role.Description)
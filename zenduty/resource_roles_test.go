@@ -0,0 +1,52 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRoles_basic(t *testing.T) {
+	name := testAccName()
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_roles"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRolesConfig(name, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_roles.test", &id),
+					resource.TestCheckResourceAttr("zenduty_roles.test", "title", name),
+				),
+			},
+			{
+				Config: testAccRolesConfig(name, 3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_roles.test", &id),
+					resource.TestCheckResourceAttr("zenduty_roles.test", "rank", "3"),
+					resource.TestCheckResourceAttr("zenduty_roles.test", "description", "Created by acceptance tests"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_roles.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_roles.test", "team"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccRolesConfig(name string, rank int) string {
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_roles" "test" {
+  team        = zenduty_teams.test.id
+  title       = %q
+  description = "Created by acceptance tests"
+  rank        = %d
+}
+`, name, rank)
+}
@@ -0,0 +1,224 @@
+package zenduty
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// scheduleOverride is an override of a schedule, with times in UTC. The SDK
+// only sends overrides along with the whole schedule, so they are managed on
+// their own with Meta.request.
+type scheduleOverride struct {
+	UniqueID  string `json:"unique_id,omitempty"`
+	Name      string `json:"name"`
+	User      string `json:"user"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+func scheduleOverridesPath(teamID, scheduleID string) string {
+	return fmt.Sprintf("/api/account/teams/%s/schedules/%s/overrides/", teamID, scheduleID)
+}
+
+func scheduleOverridePath(teamID, scheduleID, id string) string {
+	return fmt.Sprintf("/api/account/teams/%s/schedules/%s/overrides/%s/", teamID, scheduleID, id)
+}
+
+func resourceScheduleOverride() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCreateScheduleOverride,
+		ReadContext:   resourceReadScheduleOverride,
+		UpdateContext: resourceUpdateScheduleOverride,
+		DeleteContext: resourceDeleteScheduleOverride,
+		Timeouts:      resourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			State: resourceScheduleOverrideImporter,
+		},
+		CustomizeDiff: resourceScheduleOverrideCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: ValidateUUID(),
+			},
+			"schedule_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: ValidateUUID(),
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Override",
+			},
+			"user": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: ValidateRequired(),
+			},
+			"start_time": timestampSchema(true, "time_zone"),
+			"end_time":   timestampSchema(true, "time_zone"),
+			"time_zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceScheduleOverrideCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !valueKnown(d, "start_time") || !valueKnown(d, "end_time") {
+		return nil
+	}
+	// Until the time_zone is known, which it is not when it defaults to the
+	// time zone of the schedule, the times are checked in UTC.
+	timeZone := "UTC"
+	if valueKnown(d, "time_zone") && d.Get("time_zone").(string) != "" {
+		timeZone = d.Get("time_zone").(string)
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return cty.GetAttrPath("time_zone").NewError(err)
+	}
+	if err := validateScheduleOverrideTimes(d, loc); err != nil {
+		return err
+	}
+	return validateLocalTimes(d, "time_zone", "start_time", "end_time")
+}
+
+// validateScheduleOverrideTimes checks that the start_time and end_time of an
+// override are valid timestamps in loc, and that it ends after it starts.
+func validateScheduleOverrideTimes(d resourceGetter, loc *time.Location) error {
+	var parsed [2]time.Time
+	for i, key := range []string{"start_time", "end_time"} {
+		var err error
+		if parsed[i], err = parseTimestamp(d.Get(key).(string), loc); err != nil {
+			return cty.GetAttrPath(key).NewError(err)
+		}
+	}
+	if !parsed[1].After(parsed[0]) {
+		return cty.GetAttrPath("end_time").NewErrorf("end_time must be after start_time")
+	}
+	return nil
+}
+
+// scheduleOverrideTimeZone returns the time_zone of an override, which
+// defaults to the time zone of its schedule.
+func scheduleOverrideTimeZone(ctx context.Context, d *schema.ResourceData, m interface{}) (string, diag.Diagnostics) {
+	if timeZone := d.Get("time_zone").(string); timeZone != "" {
+		return timeZone, nil
+	}
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+	schedule, err := apiclient.Schedules.GetScheduleByID(d.Get("team_id").(string), d.Get("schedule_id").(string))
+	if err != nil {
+		return "", apiErrorDiags(err)
+	}
+	return schedule.TimeZone, nil
+}
+
+func buildScheduleOverrideRequest(ctx context.Context, d *schema.ResourceData, m interface{}) (*scheduleOverride, diag.Diagnostics) {
+	timeZone, diags := scheduleOverrideTimeZone(ctx, d, m)
+	if diags.HasError() {
+		return nil, diags
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, attributeDiag(cty.GetAttrPath("time_zone"), err.Error())
+	}
+	if err := validateScheduleOverrideTimes(d, loc); err != nil {
+		return nil, validationDiags(err)
+	}
+	override := &scheduleOverride{
+		Name: d.Get("name").(string),
+		User: d.Get("user").(string),
+	}
+	var timestampDiags diag.Diagnostics
+	override.StartTime, timestampDiags = toAPITimestamp(d.Get("start_time").(string), loc, cty.GetAttrPath("start_time"))
+	diags = append(diags, timestampDiags...)
+	override.EndTime, timestampDiags = toAPITimestamp(d.Get("end_time").(string), loc, cty.GetAttrPath("end_time"))
+	diags = append(diags, timestampDiags...)
+	d.Set("time_zone", timeZone)
+	return override, diags
+}
+
+func resourceCreateScheduleOverride(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	newOverride, diags := buildScheduleOverrideRequest(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+	var override scheduleOverride
+	path := scheduleOverridesPath(d.Get("team_id").(string), d.Get("schedule_id").(string))
+	if err := m.(*Meta).request(ctx, http.MethodPost, path, newOverride, &override); err != nil {
+		return apiErrorDiags(err)
+	}
+	d.SetId(override.UniqueID)
+	return append(diags, resourceReadScheduleOverride(ctx, d, m)...)
+}
+
+func resourceUpdateScheduleOverride(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	newOverride, diags := buildScheduleOverrideRequest(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+	path := scheduleOverridePath(d.Get("team_id").(string), d.Get("schedule_id").(string), d.Id())
+	if err := m.(*Meta).request(ctx, http.MethodPut, path, newOverride, nil); err != nil {
+		return apiErrorDiags(err)
+	}
+	return append(diags, resourceReadScheduleOverride(ctx, d, m)...)
+}
+
+func resourceDeleteScheduleOverride(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	path := scheduleOverridePath(d.Get("team_id").(string), d.Get("schedule_id").(string), d.Id())
+	if err := m.(*Meta).request(ctx, http.MethodDelete, path, nil, nil); err != nil {
+		return handleDeleteError(d, err)
+	}
+	return nil
+}
+
+func resourceReadScheduleOverride(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var override scheduleOverride
+	path := scheduleOverridePath(d.Get("team_id").(string), d.Get("schedule_id").(string), d.Id())
+	if err := m.(*Meta).request(ctx, http.MethodGet, path, nil, &override); err != nil {
+		return handleReadError(d, err)
+	}
+	timeZone, diags := scheduleOverrideTimeZone(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+	d.Set("time_zone", timeZone)
+	d.Set("name", override.Name)
+	d.Set("user", override.User)
+	d.Set("start_time", createScheduleLayerTimeFormat(override.StartTime, timeZone))
+	d.Set("end_time", createScheduleLayerTimeFormat(override.EndTime, timeZone))
+	return nil
+}
+
+func resourceScheduleOverrideImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("unexpected format of id (%q), expected <team_id>/<schedule_id>/<override_id>", d.Id())
+	} else if !IsValidUUID(parts[0]) {
+		return nil, fmt.Errorf("invalid team_id (%q)", parts[0])
+	} else if !IsValidUUID(parts[1]) {
+		return nil, fmt.Errorf("invalid schedule_id (%q)", parts[1])
+	} else if !IsValidUUID(parts[2]) {
+		return nil, fmt.Errorf("invalid override_id (%q)", parts[2])
+	}
+	d.Set("team_id", parts[0])
+	d.Set("schedule_id", parts[1])
+	d.SetId(parts[2])
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,92 @@
+package zenduty
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccScheduleOverride_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_schedule_override"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccScheduleOverrideConfig(name, username, "Created by acceptance tests", "2030-01-09 00:00"),
+				ExpectError: regexp.MustCompile(`end_time must be after start_time`),
+			},
+			{
+				Config: testAccScheduleOverrideConfig(name, username, "Created by acceptance tests", "2030-01-11 00:00"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_schedule_override.test", &id),
+					resource.TestCheckResourceAttrPair("zenduty_schedule_override.test", "schedule_id", "zenduty_schedules.test", "id"),
+					resource.TestCheckResourceAttr("zenduty_schedule_override.test", "time_zone", "Asia/Kolkata"),
+					resource.TestCheckResourceAttr("zenduty_schedule_override.test", "start_time", "2030-01-10 00:00"),
+					resource.TestCheckResourceAttr("zenduty_schedule_override.test", "end_time", "2030-01-11 00:00"),
+				),
+			},
+			{
+				// Updating the schedule keeps the override it ignores.
+				Config: testAccScheduleOverrideConfig(name, username, "Updated by acceptance tests", "2030-01-12 00:00"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_schedule_override.test", &id),
+					resource.TestCheckResourceAttr("zenduty_schedule_override.test", "end_time", "2030-01-12 00:00"),
+					resource.TestCheckResourceAttr("zenduty_schedules.test", "summary", "Updated by acceptance tests"),
+					resource.TestCheckResourceAttr("zenduty_schedules.test", "overrides.#", "0"),
+				),
+			},
+			{
+				// The same end_time as an RFC 3339 timestamp is no change.
+				Config:   testAccScheduleOverrideConfig(name, username, "Updated by acceptance tests", "2030-01-11T18:30:00Z"),
+				PlanOnly: true,
+			},
+			{
+				Config:      testAccScheduleOverrideConfig(name, username, "Updated by acceptance tests", "2030-01-12 24:00"),
+				ExpectError: regexp.MustCompile(`neither an RFC 3339 timestamp nor a time in the format YYYY-MM-DD HH:MM`),
+			},
+			{
+				ResourceName:      "zenduty_schedule_override.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_schedule_override.test", "team_id", "schedule_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccScheduleOverrideConfig adds a schedule that ignores overrides to the
+// team of testAccTeamConfig, and an override of it putting username on call
+// from 2030-01-10 00:00 until endTime.
+func testAccScheduleOverrideConfig(name, username, summary, endTime string) string {
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_schedules" "test" {
+  name             = %[1]q
+  summary          = %[3]q
+  time_zone        = "Asia/Kolkata"
+  team_id          = zenduty_teams.test.id
+  ignore_overrides = true
+
+  layers {
+    name                = "Weekly"
+    shift_length        = 604800
+    rotation_start_time = "2030-01-07 09:00"
+    users               = [%[2]q]
+  }
+}
+
+resource "zenduty_schedule_override" "test" {
+  team_id     = zenduty_teams.test.id
+  schedule_id = zenduty_schedules.test.id
+  name        = "Vacation cover"
+  user        = %[2]q
+  start_time  = "2030-01-10 00:00"
+  end_time    = %[4]q
+}
+`, name, username, summary, endTime)
+}
@@ -2,14 +2,16 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"log"
+	"net/http"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -20,10 +22,12 @@ func resourceSchedules() *schema.Resource {
 		CreateContext: resourceCreateSchedule,
 		UpdateContext: resourceUpdateSchedule,
 		DeleteContext: resourceDeleteSchedule,
-		ReadContext:   wrapReadWith404(resourceReadSchedule),
+		ReadContext:   resourceReadSchedule,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourceScheduleImporter,
 		},
+		CustomizeDiff: resourceSchedulesCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -46,89 +50,104 @@ func resourceSchedules() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-			"layers": &schema.Schema{
+			"layers":    scheduleLayersSchema(),
+			"overrides": scheduleOverridesSchema(),
+			"ignore_overrides": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"overrides"},
+			},
+			"coverage_check": {
 				Type:     schema.TypeList,
 				Optional: true,
+				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"shift_length": {
+						"from": timestampSchema(false, "time_zone"),
+						"horizon_days": {
 							Type:         schema.TypeInt,
-							Required:     true,
-							ValidateFunc: validation.IntBetween(3600, 365*24*3600),
-						},
-						"rotation_start_time": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"rotation_end_time": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Optional:     true,
+							Default:      14,
+							ValidateFunc: validation.IntBetween(1, 366),
 						},
-						"users": {
-							Type:     schema.TypeList,
-							Required: true,
-							MinItems: 1,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
+						"mode": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      coverageCheckWarn,
+							ValidateFunc: validation.StringInSlice([]string{coverageCheckWarn, coverageCheckError}, false),
+							Description:  "In warn mode, intervals found at plan time are only written to the provider log; they are shown as a warning on apply. In error mode, they fail the plan.",
 						},
-						"restriction_type": {
+						"max_overlap": {
 							Type:         schema.TypeInt,
 							Optional:     true,
-							ValidateFunc: validation.IntBetween(0, 2),
 							Default:      0,
-						},
-						"restrictions": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"duration": {
-										Type:         schema.TypeInt,
-										Required:     true,
-										ValidateFunc: validation.IntBetween(1, 7*24*3600),
-									},
-									"start_day_of_week": {
-										Type:         schema.TypeInt,
-										Required:     true,
-										ValidateFunc: validation.IntBetween(1, 7),
-									},
-									"start_time_of_day": {
-										Type:         schema.TypeString,
-										Required:     true,
-										ValidateFunc: validation.StringMatch(regexp.MustCompile(`^([0-9]|0[0-9]|1[0-9]|2[0-3]):([0-9]|[0-5][0-9]):([0-9]|[0-5][0-9])$`), "must be in the format HH:MM:SS"),
-									},
-								},
-							},
+							ValidateFunc: validation.IntAtLeast(0),
 						},
 					},
 				},
 			},
-			"overrides": &schema.Schema{
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"start_time": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"end_time": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"user": {
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-4[a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{1}$`), "must be a valid user id"),
+		},
+	}
+}
+
+// scheduleLayersSchema is the schema of the layers of a schedule, shared by
+// zenduty_schedules and zenduty_schedule_preview.
+func scheduleLayersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"unique_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"shift_length": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntBetween(3600, 365*24*3600),
+				},
+				"rotation_start_time": timestampSchema(true, "time_zone"),
+				"rotation_end_time":   timestampSchema(false, "time_zone"),
+				"users": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"restriction_type": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntBetween(0, 2),
+					Default:      0,
+				},
+				"restrictions": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"duration": {
+								Type:         schema.TypeInt,
+								Required:     true,
+								ValidateFunc: validation.IntBetween(1, 7*24*3600),
+							},
+							"start_day_of_week": {
+								Type:         schema.TypeInt,
+								Required:     true,
+								ValidateFunc: validation.IntBetween(1, 7),
+							},
+							"start_time_of_day": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringMatch(regexp.MustCompile(`^([0-9]|0[0-9]|1[0-9]|2[0-3]):([0-9]|[0-5][0-9]):([0-9]|[0-5][0-9])$`), "must be in the format HH:MM:SS"),
+							},
 						},
 					},
 				},
@@ -137,7 +156,92 @@ func resourceSchedules() *schema.Resource {
 	}
 }
 
-func buildScheduleLayerRescrition(newLayer *client.CreateLayers, layerMap map[string]interface{}, d *schema.ResourceData) ([]client.Restrictions, diag.Diagnostics) {
+// scheduleOverridesSchema is the schema of the overrides of a schedule, shared
+// by zenduty_schedules and zenduty_schedule_preview.
+func scheduleOverridesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"start_time": timestampSchema(true, "time_zone"),
+				"end_time":   timestampSchema(true, "time_zone"),
+				"user": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-4[a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{1}$`), "must be a valid user id"),
+				},
+			},
+		},
+	}
+}
+
+func resourceSchedulesCustomizeDiff(Ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if err := validateScheduleRestrictions(d); err != nil {
+		return err
+	}
+	if err := validateLocalTimes(d, "time_zone", scheduleTimestampKeys(d)...); err != nil {
+		return err
+	}
+	return validateScheduleCoverage(Ctx, d)
+}
+
+// scheduleTimestampKeys returns the keys of the timestamps of a schedule.
+func scheduleTimestampKeys(d resourceGetter) []string {
+	var keys []string
+	for i := range d.Get("layers").([]interface{}) {
+		keys = append(keys, fmt.Sprintf("layers.%d.rotation_start_time", i), fmt.Sprintf("layers.%d.rotation_end_time", i))
+	}
+	for i := range d.Get("overrides").([]interface{}) {
+		keys = append(keys, fmt.Sprintf("overrides.%d.start_time", i), fmt.Sprintf("overrides.%d.end_time", i))
+	}
+	if len(d.Get("coverage_check").([]interface{})) > 0 {
+		keys = append(keys, "coverage_check.0.from")
+	}
+	return keys
+}
+
+// validateScheduleRestrictions checks that layers with restrictions set a
+// restriction_type and that every restriction fits within a day for daily
+// restrictions or a week for weekly ones.
+func validateScheduleRestrictions(d resourceGetter) error {
+	layers := d.Get("layers").([]interface{})
+	for i, layer := range layers {
+		layerMap, ok := layer.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		path := cty.GetAttrPath("layers").IndexInt(i)
+		if !valueKnown(d, fmt.Sprintf("layers.%d.restriction_type", i)) {
+			continue
+		}
+		restrictionType := layerMap["restriction_type"].(int)
+		restrictions, _ := layerMap["restrictions"].([]interface{})
+		if len(restrictions) > 0 && restrictionType == 0 {
+			return path.GetAttr("restriction_type").NewErrorf("restrictions must be set to add restrictions.. ie daily(1) or weekly(2)")
+		}
+		for j, restriction := range restrictions {
+			restrictionMap, ok := restriction.(map[string]interface{})
+			if !ok || !valueKnown(d, fmt.Sprintf("layers.%d.restrictions.%d.duration", i, j)) {
+				continue
+			}
+			duration := restrictionMap["duration"].(int)
+			durationPath := path.GetAttr("restrictions").IndexInt(j).GetAttr("duration")
+			if restrictionType == 1 && duration >= 86400 {
+				return durationPath.NewErrorf("duration must be less than 86400 for daily restriction ie 24 hours")
+			} else if restrictionType == 2 && duration >= 604800 {
+				return durationPath.NewErrorf("duration must be less than 604800 for weekly restriction ie 7 days")
+			}
+		}
+	}
+	return nil
+}
+
+func buildScheduleLayerRescrition(newLayer *client.CreateLayers, layerMap map[string]interface{}) []client.Restrictions {
 	if v, ok := layerMap["restriction_type"]; ok {
 		newLayer.RestrictionType = v.(int)
 	}
@@ -146,18 +250,10 @@ func buildScheduleLayerRescrition(newLayer *client.CreateLayers, layerMap map[st
 		restrictions := v.([]interface{})
 		Restrictions := make([]client.Restrictions, len(restrictions))
 		for j, restriction := range restrictions {
-			if newLayer.RestrictionType == 0 {
-				return nil, diag.FromErr(errors.New("restrictions must be set to add restrictions.. ie daily(1) or weekly(2)"))
-			}
 			restrictionMap := restriction.(map[string]interface{})
 			newRestriction := client.Restrictions{}
 			if v, ok := restrictionMap["duration"]; ok {
 				newRestriction.Duration = v.(int)
-				if newLayer.RestrictionType == 1 && newRestriction.Duration >= 86400 {
-					return nil, diag.FromErr(errors.New("duration must be less than 86400 for daily restriction ie 24 hours"))
-				} else if newLayer.RestrictionType == 2 && newRestriction.Duration >= 604800 {
-					return nil, diag.FromErr(errors.New("duration must be less than 604800 for weekly restriction ie 7 days"))
-				}
 			}
 			if v, ok := restrictionMap["start_day_of_week"]; ok {
 
@@ -174,22 +270,28 @@ func buildScheduleLayerRescrition(newLayer *client.CreateLayers, layerMap map[st
 
 			Restrictions[j] = newRestriction
 		}
-		return Restrictions, nil
+		return Restrictions
 	}
-	return nil, nil
+	return nil
 }
 
-func buildScheduleLayer(ctx context.Context, d *schema.ResourceData, TimeZone string) ([]client.CreateLayers, diag.Diagnostics) {
+func buildScheduleLayer(ctx context.Context, d resourceGetter, TimeZone string) ([]client.CreateLayers, diag.Diagnostics) {
 	layers := d.Get("layers").([]interface{})
 	Layers := make([]client.CreateLayers, len(layers))
+	loc, zoneErr := time.LoadLocation(TimeZone)
+	if zoneErr != nil {
+		return nil, attributeDiag(cty.GetAttrPath("time_zone"), zoneErr.Error())
+	}
 
+	var diags diag.Diagnostics
 	for i, layer := range layers {
 		layerMap := layer.(map[string]interface{})
 		newLayer := client.CreateLayers{}
+		path := cty.GetAttrPath("layers").IndexInt(i)
 
 		if v, ok := layerMap["name"]; ok {
 			if v.(string) == "" {
-				return nil, diag.FromErr(errors.New("name must not be empty"))
+				return nil, attributeDiag(path.GetAttr("name"), "name must not be empty")
 			}
 
 			newLayer.Name = v.(string)
@@ -202,24 +304,20 @@ func buildScheduleLayer(ctx context.Context, d *schema.ResourceData, TimeZone st
 			newLayer.ShiftLength = v.(int)
 		}
 		if v, ok := layerMap["rotation_start_time"]; ok {
-
-			newLayer.RotationStartTime = v.(string)
-			loc, _ := time.LoadLocation(TimeZone)
-
-			parsedTime, parseErr := time.ParseInLocation("2006-01-02 15:04", v.(string), loc)
-			if parseErr == nil {
-				newLayer.RotationStartTime = parsedTime.In(time.UTC).Format(time.RFC3339)
+			rotationStartTime, timestampDiags := toAPITimestamp(v.(string), loc, path.GetAttr("rotation_start_time"))
+			diags = append(diags, timestampDiags...)
+			if diags.HasError() {
+				return nil, diags
 			}
-
+			newLayer.RotationStartTime = rotationStartTime
 		}
-		if v, ok := layerMap["rotation_end_time"]; ok {
-			newLayer.RotationEndTime = v.(string)
-			loc, _ := time.LoadLocation(TimeZone)
-			parsedTime, parsedErr := time.ParseInLocation("2006-01-02 15:04", v.(string), loc)
-			if parsedErr == nil {
-				newLayer.RotationEndTime = parsedTime.In(time.UTC).Format(time.RFC3339)
+		if v, ok := layerMap["rotation_end_time"]; ok && v.(string) != "" {
+			rotationEndTime, timestampDiags := toAPITimestamp(v.(string), loc, path.GetAttr("rotation_end_time"))
+			diags = append(diags, timestampDiags...)
+			if diags.HasError() {
+				return nil, diags
 			}
-
+			newLayer.RotationEndTime = rotationEndTime
 		}
 		if v, ok := layerMap["users"]; ok {
 			users := v.([]interface{})
@@ -230,70 +328,226 @@ func buildScheduleLayer(ctx context.Context, d *schema.ResourceData, TimeZone st
 				newLayer.Users[j] = newUser
 			}
 		}
-		newRestriction, restrictionErr := buildScheduleLayerRescrition(&newLayer, layerMap, d)
-		if restrictionErr != nil {
-			return nil, restrictionErr
-		}
+		newRestriction := buildScheduleLayerRescrition(&newLayer, layerMap)
 		if newRestriction != nil {
 			newLayer.Restrictions = newRestriction
 		}
 		Layers[i] = newLayer
 	}
-	return Layers, nil
+	return Layers, diags
 
 }
 
-func buildScheduleOverride(newSchedule *client.CreateSchedule, d *schema.ResourceData) ([]client.Overrides, diag.Diagnostics) {
+func buildScheduleOverride(newSchedule *client.CreateSchedule, d resourceGetter) ([]client.Overrides, diag.Diagnostics) {
 	overrides := d.Get("overrides").([]interface{})
 	Overrides := make([]client.Overrides, len(overrides))
+	loc, zoneErr := time.LoadLocation(newSchedule.TimeZone)
+	if zoneErr != nil {
+		return nil, attributeDiag(cty.GetAttrPath("time_zone"), zoneErr.Error())
+	}
 
+	var diags diag.Diagnostics
 	for o, override := range overrides {
 		override := override.(map[string]interface{})
 		newOverride := client.Overrides{}
+		path := cty.GetAttrPath("overrides").IndexInt(o)
 
 		if v, ok := override["name"]; ok {
 
 			newOverride.Name = v.(string)
 		}
 		if v, ok := override["start_time"]; ok {
-
-			newOverride.StartTime = v.(string)
-
-			loc, zoneErr := time.LoadLocation(newSchedule.TimeZone)
-			if zoneErr != nil {
-				return nil, diag.FromErr(errors.New(zoneErr.Error()))
+			startTime, timestampDiags := toAPITimestamp(v.(string), loc, path.GetAttr("start_time"))
+			diags = append(diags, timestampDiags...)
+			if diags.HasError() {
+				return nil, diags
 			}
-			parsedTime, err := time.ParseInLocation("2006-01-02 15:04", v.(string), loc)
-			if err != nil {
-				return nil, diag.FromErr(errors.New(err.Error()))
+			newOverride.StartTime = startTime
+		}
+		if v, ok := override["end_time"]; ok {
+			endTime, timestampDiags := toAPITimestamp(v.(string), loc, path.GetAttr("end_time"))
+			diags = append(diags, timestampDiags...)
+			if diags.HasError() {
+				return nil, diags
 			}
+			newOverride.EndTime = endTime
+		}
+		if v, ok := override["user"]; ok {
 
-			newOverride.StartTime = parsedTime.In(time.UTC).Format(time.RFC3339)
+			newOverride.User = v.(string)
+		}
+		Overrides[o] = newOverride
+	}
+	return Overrides, diags
+}
 
+// scheduleFromConfig builds the oncallSchedule of the layers and overrides in
+// the configuration of a schedule.
+func scheduleFromConfig(ctx context.Context, d resourceGetter, timeZone string) (*oncallSchedule, diag.Diagnostics) {
+	createLayers, diags := buildScheduleLayer(ctx, d, timeZone)
+	if diags.HasError() {
+		return nil, diags
+	}
+	overrides, overrideDiags := buildScheduleOverride(&client.CreateSchedule{TimeZone: timeZone}, d)
+	diags = append(diags, overrideDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	layers := make([]client.Layers, len(createLayers))
+	for i, layer := range createLayers {
+		layers[i] = client.Layers{
+			Name:              layer.Name,
+			ShiftLength:       layer.ShiftLength,
+			RotationStartTime: layer.RotationStartTime,
+			RotationEndTime:   layer.RotationEndTime,
+			RestrictionType:   layer.RestrictionType,
+			Restrictions:      layer.Restrictions,
 		}
-		if v, ok := override["end_time"]; ok {
-			newOverride.EndTime = v.(string)
+		for _, user := range layer.Users {
+			layers[i].Users = append(layers[i].Users, client.Users{User: user.User})
+		}
+	}
 
-			loc, zoneErr := time.LoadLocation(newSchedule.TimeZone)
-			if zoneErr != nil {
-				return nil, diag.FromErr(errors.New(zoneErr.Error()))
-			}
-			parsedEndTime, err := time.ParseInLocation("2006-01-02 15:04", newOverride.EndTime, loc)
+	schedule, err := newOncallSchedule(timeZone, layers, overrides)
+	if err != nil {
+		return nil, attributeDiag(cty.GetAttrPath("layers"), err.Error())
+	}
+	return schedule, diags
+}
 
-			if err != nil {
-				return nil, diag.FromErr(errors.New(err.Error()))
+// scheduleConfigKnown reports whether every value of the time zone, layers
+// and overrides of a schedule is known.
+func scheduleConfigKnown(d resourceGetter) bool {
+	keys := []string{"time_zone", "layers", "overrides"}
+	for i, layer := range d.Get("layers").([]interface{}) {
+		prefix := fmt.Sprintf("layers.%d.", i)
+		keys = append(keys, prefix+"name", prefix+"shift_length", prefix+"rotation_start_time", prefix+"rotation_end_time", prefix+"users", prefix+"restriction_type", prefix+"restrictions")
+		layerMap, _ := layer.(map[string]interface{})
+		users, _ := layerMap["users"].([]interface{})
+		for j := range users {
+			keys = append(keys, fmt.Sprintf("%susers.%d", prefix, j))
+		}
+		restrictions, _ := layerMap["restrictions"].([]interface{})
+		for j := range restrictions {
+			for _, key := range []string{"duration", "start_day_of_week", "start_time_of_day"} {
+				keys = append(keys, fmt.Sprintf("%srestrictions.%d.%s", prefix, j, key))
 			}
+		}
+	}
+	for i := range d.Get("overrides").([]interface{}) {
+		for _, key := range []string{"start_time", "end_time", "user"} {
+			keys = append(keys, fmt.Sprintf("overrides.%d.%s", i, key))
+		}
+	}
+	for _, key := range keys {
+		if !valueKnown(d, key) {
+			return false
+		}
+	}
+	return true
+}
 
-			newOverride.EndTime = parsedEndTime.In(time.UTC).Format(time.RFC3339)
+// Modes of the coverage_check of a schedule.
+const (
+	coverageCheckWarn  = "warn"
+	coverageCheckError = "error"
+)
 
-		}
-		if v, ok := override["user"]; ok {
+// maxCoverageIssues is the number of intervals listed by a coverage check
+// before the rest are only counted.
+const maxCoverageIssues = 10
+
+// scheduleCoverageIssues runs the coverage_check of a schedule over
+// horizon_days, from its from time or else from the earliest start of the
+// layers and overrides of the schedule, so that the result of the check does
+// not depend on when it runs. It returns the mode of the check and a description of every
+// interval it found, or no intervals when the check is not enabled or the
+// schedule is not known yet.
+func scheduleCoverageIssues(ctx context.Context, d resourceGetter) (string, []string, diag.Diagnostics) {
+	checks := d.Get("coverage_check").([]interface{})
+	if len(checks) == 0 || checks[0] == nil || !scheduleConfigKnown(d) || !valueKnown(d, "coverage_check.0.from") {
+		return "", nil, nil
+	}
+	check := checks[0].(map[string]interface{})
 
-			newOverride.User = v.(string)
+	timeZone := d.Get("time_zone").(string)
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return "", nil, attributeDiag(cty.GetAttrPath("time_zone"), err.Error())
+	}
+	schedule, diags := scheduleFromConfig(ctx, d, timeZone)
+	if diags.HasError() {
+		return "", nil, diags
+	}
+
+	from := schedule.start()
+	if v := check["from"].(string); v != "" {
+		// The format was checked by validateTimestamp.
+		from, _ = parseTimestamp(v, loc)
+	}
+	if from.IsZero() {
+		return check["mode"].(string), []string{"nobody on call, as the schedule has no layers or overrides"}, nil
+	}
+	to := from.AddDate(0, 0, check["horizon_days"].(int))
+	gaps, overlaps := schedule.coverage(from, to, check["max_overlap"].(int))
+	var issues []string
+	for _, gap := range gaps {
+		issues = append(issues, fmt.Sprintf("nobody on call from %s to %s", gap.start.In(loc).Format(localTimestampFormat), gap.end.In(loc).Format(localTimestampFormat)))
+	}
+	for _, overlap := range overlaps {
+		issues = append(issues, fmt.Sprintf("%d layers on call from %s to %s", overlap.layers, overlap.start.In(loc).Format(localTimestampFormat), overlap.end.In(loc).Format(localTimestampFormat)))
+	}
+	return check["mode"].(string), issues, nil
+}
+
+// coverageSummary joins the issues found by a coverage check into one
+// message.
+func coverageSummary(horizonDays int, issues []string) string {
+	summary := fmt.Sprintf("coverage check over %d days found %d intervals:", horizonDays, len(issues))
+	for i, issue := range issues {
+		if i == maxCoverageIssues {
+			return summary + fmt.Sprintf("\n- and %d more", len(issues)-i)
 		}
-		Overrides[o] = newOverride
+		summary += "\n- " + issue
+	}
+	return summary
+}
+
+// validateScheduleCoverage fails the plan when the coverage_check of a
+// schedule is in error mode and finds intervals. CustomizeDiff cannot return
+// warnings, so in warn mode they are only logged at plan time, and surface as
+// a warning from scheduleCoverageDiags when the schedule is applied.
+func validateScheduleCoverage(ctx context.Context, d resourceGetter) error {
+	mode, issues, diags := scheduleCoverageIssues(ctx, d)
+	if diags.HasError() || len(issues) == 0 {
+		// Errors in the layers are reported by createSchedule.
+		return nil
 	}
-	return Overrides, nil
+	horizonDays := d.Get("coverage_check.0.horizon_days").(int)
+	if mode == coverageCheckError {
+		return cty.GetAttrPath("coverage_check").NewErrorf("%s", coverageSummary(horizonDays, issues))
+	}
+	log.Printf("[WARN] schedule %q: %s", d.Get("name").(string), coverageSummary(horizonDays, issues))
+	return nil
+}
+
+// scheduleCoverageDiags returns the intervals found by the coverage_check of
+// a schedule, as an error in error mode and as a warning in warn mode.
+func scheduleCoverageDiags(ctx context.Context, d resourceGetter) diag.Diagnostics {
+	mode, issues, diags := scheduleCoverageIssues(ctx, d)
+	if diags.HasError() || len(issues) == 0 {
+		return diags
+	}
+	severity := diag.Warning
+	if mode == coverageCheckError {
+		severity = diag.Error
+	}
+	return diag.Diagnostics{{
+		Severity:      severity,
+		Summary:       fmt.Sprintf("Schedule %q is not covered as expected", d.Get("name").(string)),
+		Detail:        coverageSummary(d.Get("coverage_check.0.horizon_days").(int), issues),
+		AttributePath: cty.GetAttrPath("coverage_check"),
+	}}
 }
 
 func createSchedule(Ctx context.Context, d *schema.ResourceData, m interface{}) (*client.CreateSchedule, diag.Diagnostics) {
@@ -301,7 +555,7 @@ func createSchedule(Ctx context.Context, d *schema.ResourceData, m interface{})
 
 	if v, ok := d.GetOk("name"); ok {
 		if v.(string) == "" {
-			return nil, diag.FromErr(errors.New("name must not be empty"))
+			return nil, attributeDiag(cty.GetAttrPath("name"), "name must not be empty")
 		}
 		newSchedule.Name = v.(string)
 	}
@@ -313,118 +567,256 @@ func createSchedule(Ctx context.Context, d *schema.ResourceData, m interface{})
 	}
 	if v, ok := d.GetOk("time_zone"); ok {
 		if emptyString(v.(string)) {
-			return nil, diag.FromErr(errors.New("time_zone must not be empty"))
+			return nil, attributeDiag(cty.GetAttrPath("time_zone"), "time_zone must not be empty")
 		}
 		_, zoneErr := time.LoadLocation(v.(string))
 		if zoneErr != nil {
-			return nil, diag.FromErr(errors.New(zoneErr.Error()))
+			return nil, attributeDiag(cty.GetAttrPath("time_zone"), zoneErr.Error())
 		}
 		newSchedule.TimeZone = v.(string)
 
 	}
 	if v, ok := d.GetOk("team_id"); ok {
 		if emptyString(v.(string)) {
-			return nil, diag.FromErr(errors.New("team_id must not be empty"))
+			return nil, attributeDiag(cty.GetAttrPath("team_id"), "team_id must not be empty")
 		}
 		newSchedule.Team = v.(string)
 
 	}
 
-	Layers, layerErr := buildScheduleLayer(Ctx, d, newSchedule.TimeZone)
-	if layerErr != nil {
-		return nil, layerErr
+	if err := validateScheduleRestrictions(d); err != nil {
+		return nil, validationDiags(err)
+	}
+	Layers, diags := buildScheduleLayer(Ctx, d, newSchedule.TimeZone)
+	if diags.HasError() {
+		return nil, diags
 	}
-	Overrides, overrideErr := buildScheduleOverride(newSchedule, d)
-	if overrideErr != nil {
-		return nil, overrideErr
+	Overrides, overrideDiags := buildScheduleOverride(newSchedule, d)
+	diags = append(diags, overrideDiags...)
+	if diags.HasError() {
+		return nil, diags
 	}
 
 	newSchedule.Overrides = Overrides
 	newSchedule.Layers = Layers
 
-	return newSchedule, nil
+	return newSchedule, append(diags, scheduleCoverageDiags(Ctx, d)...)
 }
 
 func resourceCreateSchedule(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
-	var diags diag.Diagnostics
-	newSchedule, createError := createSchedule(Ctx, d, m)
-	if createError != nil {
-		return createError
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	newSchedule, diags := createSchedule(Ctx, d, m)
+	if diags.HasError() {
+		return diags
 	}
 	schedule, err := apiclient.Schedules.CreateSchedule(newSchedule.Team, newSchedule)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	d.SetId(schedule.UniqueID)
-	return diags
-
+	return append(diags, resourceReadSchedule(Ctx, d, m)...)
 }
 
 func resourceUpdateSchedule(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	teamID := d.Get("team_id").(string)
 	id := d.Id()
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
-	var diags diag.Diagnostics
-	newSchedule, createError := createSchedule(Ctx, d, m)
-	if createError != nil {
-		return createError
+	newSchedule, diags := createSchedule(Ctx, d, m)
+	if diags.HasError() {
+		return diags
 	}
-	_, err := apiclient.Schedules.UpdateScheduleByID(teamID, id, newSchedule)
-	if err != nil {
+	// Send every layer with the unique_id of the layer it was before, so
+	// the API updates it in place and keeps its rotation.
+	oldLayers, _ := d.GetChange("layers")
+	layers := d.Get("layers").([]interface{})
+	matches := matchScheduleLayers(scheduleLayerKeys(layers, false), scheduleLayerKeys(oldLayers.([]interface{}), true))
+	update := scheduleUpdate{CreateSchedule: newSchedule}
+	for i, layer := range newSchedule.Layers {
+		update.Layers = append(update.Layers, scheduleLayerUpdate{CreateLayers: layer})
+		layerMap := layers[i].(map[string]interface{})
+		layerMap["unique_id"] = ""
+		if j := matches[i]; j >= 0 {
+			update.Layers[i].UniqueID = oldLayers.([]interface{})[j].(map[string]interface{})["unique_id"].(string)
+			layerMap["unique_id"] = update.Layers[i].UniqueID
+		}
+	}
+	if d.Get("ignore_overrides").(bool) {
+		// The schedule is updated as a whole, so send back the overrides
+		// managed elsewhere, such as by zenduty_schedule_override.
+		current, err := apiclient.Schedules.GetScheduleByID(teamID, id)
+		if err != nil {
+			return apiErrorDiags(err)
+		}
+		newSchedule.Overrides = current.Overrides
+	}
+	if err := m.(*Meta).request(Ctx, http.MethodPut, schedulePath(teamID, id), update, nil); err != nil {
+		return apiErrorDiags(err)
+	}
+	// Read matches the layers it reads to these by unique_id, and to new
+	// layers by name.
+	if err := d.Set("layers", layers); err != nil {
 		return diag.FromErr(err)
 	}
-	return diags
+	return append(diags, resourceReadSchedule(Ctx, d, m)...)
 }
 
 func resourceDeleteSchedule(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	id := d.Id()
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	var diags diag.Diagnostics
-	err := apiclient.Schedules.DeleteScheduleByID(teamID, id)
+	err = apiclient.Schedules.DeleteScheduleByID(teamID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return diags
 }
 
 func resourceReadSchedule(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	id := d.Id()
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	var diags diag.Diagnostics
 	service, err := apiclient.Schedules.GetScheduleByID(teamID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.Set("name", service.Name)
 	d.Set("summary", service.Summary)
 	d.Set("description", service.Description)
 	d.Set("time_zone", service.TimeZone)
 	d.Set("team_id", service.Team)
-	if err := d.Set("layers", flattenLayer(service.TimeZone, service.Layers)); err != nil {
+	layers := orderScheduleLayers(service.Layers, d.Get("layers").([]interface{}))
+	if err := d.Set("layers", flattenLayer(service.TimeZone, layers)); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("overrides", flattenScheduleOverrides(service.TimeZone, service.Overrides)); err != nil {
-		return diag.FromErr(err)
+	if !d.Get("ignore_overrides").(bool) {
+		if err := d.Set("overrides", flattenScheduleOverrides(service.TimeZone, service.Overrides)); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return diags
 }
 
+func schedulePath(teamID, id string) string {
+	return fmt.Sprintf("/api/account/teams/%s/schedules/%s/", teamID, id)
+}
+
+// scheduleUpdate is the body of an update of a schedule. The SDK does not
+// send the unique_id of layers, without which the API replaces every layer
+// and resets its rotation, so updates are sent with Meta.request.
+type scheduleUpdate struct {
+	*client.CreateSchedule
+	Layers []scheduleLayerUpdate `json:"layers"`
+}
+
+type scheduleLayerUpdate struct {
+	client.CreateLayers
+	UniqueID string `json:"unique_id,omitempty"`
+}
+
+// scheduleLayerKey identifies a layer of a schedule.
+type scheduleLayerKey struct {
+	uniqueID string
+	name     string
+}
+
+// scheduleLayerKeys returns the keys of the layers in a list of layer
+// blocks, leaving out their unique_id unless withID is set.
+func scheduleLayerKeys(layers []interface{}, withID bool) []scheduleLayerKey {
+	keys := make([]scheduleLayerKey, len(layers))
+	for i, layer := range layers {
+		layerMap, _ := layer.(map[string]interface{})
+		keys[i].name, _ = layerMap["name"].(string)
+		if withID {
+			keys[i].uniqueID, _ = layerMap["unique_id"].(string)
+		}
+	}
+	return keys
+}
+
+// matchScheduleLayers returns for every layer the index of the candidate it
+// is the same layer as, or -1 if it is a new one. Layers are matched by
+// unique_id, then by name, then by position, each candidate at most once.
+func matchScheduleLayers(layers, candidates []scheduleLayerKey) []int {
+	matches := make([]int, len(layers))
+	used := make([]bool, len(candidates))
+	for i := range matches {
+		matches[i] = -1
+	}
+	rules := []func(a, b scheduleLayerKey, i, j int) bool{
+		func(a, b scheduleLayerKey, i, j int) bool { return a.uniqueID != "" && a.uniqueID == b.uniqueID },
+		func(a, b scheduleLayerKey, i, j int) bool { return a.name != "" && a.name == b.name },
+		func(a, b scheduleLayerKey, i, j int) bool { return i == j },
+	}
+	for _, same := range rules {
+		for i, layer := range layers {
+			if matches[i] >= 0 {
+				continue
+			}
+			for j, candidate := range candidates {
+				if !used[j] && same(layer, candidate, i, j) {
+					matches[i] = j
+					used[j] = true
+					break
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// orderScheduleLayers orders the layers read from the API like the layers in
+// the state, so that the API listing them in another order does not show as a
+// change. Layers that are not in the state come last.
+func orderScheduleLayers(layers []client.Layers, prior []interface{}) []client.Layers {
+	keys := make([]scheduleLayerKey, len(layers))
+	for i, layer := range layers {
+		keys[i] = scheduleLayerKey{uniqueID: layer.UniqueID, name: layer.Name}
+	}
+	matches := matchScheduleLayers(scheduleLayerKeys(prior, true), keys)
+
+	ordered := make([]client.Layers, 0, len(layers))
+	placed := make([]bool, len(layers))
+	for _, j := range matches {
+		if j >= 0 {
+			ordered = append(ordered, layers[j])
+			placed[j] = true
+		}
+	}
+	for j, layer := range layers {
+		if !placed[j] {
+			ordered = append(ordered, layer)
+		}
+	}
+	return ordered
+}
+
 func flattenLayer(TimeZone string, layers []client.Layers) []map[string]interface{} {
 
 	var layerList []map[string]interface{}
@@ -433,6 +825,7 @@ func flattenLayer(TimeZone string, layers []client.Layers) []map[string]interfac
 			layer.Name = fmt.Sprintf("Layer-%d", i+1)
 		}
 		layerList = append(layerList, map[string]interface{}{
+			"unique_id":           layer.UniqueID,
 			"name":                layer.Name,
 			"shift_length":        layer.ShiftLength,
 			"rotation_start_time": createScheduleLayerTimeFormat(layer.RotationStartTime, TimeZone),
@@ -447,18 +840,11 @@ func flattenLayer(TimeZone string, layers []client.Layers) []map[string]interfac
 }
 
 func createScheduleLayerTimeFormat(timestamp, Zone string) string {
-	RFC3339local := "2006-01-02T15:04:05Z"
-	loc, zoneErr := time.LoadLocation(Zone)
-	if zoneErr != nil {
-		return timestamp
-	}
-	t, parseErr := time.ParseInLocation(RFC3339local, timestamp, time.UTC)
-	if parseErr != nil {
+	local, err := fromAPITimestamp(timestamp, Zone)
+	if err != nil {
 		return timestamp
 	}
-
-	return t.In(loc).Format("2006-01-02 15:04")
-
+	return local
 }
 
 func flattenLayerUsers(users []client.Users) []string {
@@ -517,6 +903,7 @@ func resourceScheduleImporter(d *schema.ResourceData, m interface{}) ([]*schema.
 	}
 
 	d.Set("team_id", parts[0])
+	d.Set("ignore_overrides", false)
 	d.SetId(parts[1])
 	return []*schema.ResourceData{d}, nil
 }
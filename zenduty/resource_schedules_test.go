@@ -0,0 +1,278 @@
+package zenduty
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccSchedules_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_schedules"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchedulesConfig(name, username, 86400),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_schedules.test", &id),
+					resource.TestCheckResourceAttr("zenduty_schedules.test", "layers.#", "1"),
+					resource.TestCheckResourceAttr("zenduty_schedules.test", "layers.0.rotation_start_time", "2030-01-07 09:00"),
+					resource.TestCheckResourceAttr("zenduty_schedules.test", "layers.0.restrictions.#", "1"),
+				),
+			},
+			{
+				Config: testAccSchedulesConfig(name, username, 7*86400),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_schedules.test", &id),
+					resource.TestCheckResourceAttr("zenduty_schedules.test", "layers.0.shift_length", "604800"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_schedules.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_schedules.test", "team_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccSchedulesConfig adds a schedule with one layer on call for username
+// during business hours to the team of testAccTeamConfig.
+func testAccSchedulesConfig(name, username string, shiftLength int) string {
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_schedules" "test" {
+  name      = %[1]q
+  summary   = "Created by acceptance tests"
+  time_zone = "Asia/Kolkata"
+  team_id   = zenduty_teams.test.id
+
+  layers {
+    name                = "Business hours"
+    shift_length        = %[3]d
+    rotation_start_time = "2030-01-07 09:00"
+    users               = [%[2]q]
+    restriction_type    = 1
+
+    restrictions {
+      duration          = 32400
+      start_day_of_week = 7
+      start_time_of_day = "09:00:00"
+    }
+  }
+}
+`, name, username, shiftLength)
+}
+
+func TestAccSchedules_coverageCheck(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_schedules"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSchedulesCoverageConfig(name, username, false),
+				ExpectError: regexp.MustCompile(`nobody on call from`),
+			},
+			{
+				Config: testAccSchedulesCoverageConfig(name, username, true),
+				Check:  resource.TestCheckResourceAttr("zenduty_schedules.test", "coverage_check.0.mode", "error"),
+			},
+		},
+	})
+}
+
+// testAccSchedulesCoverageConfig adds a schedule to the team of
+// testAccTeamConfig whose only layer is on call on weekdays, and on weekends
+// too if weekends is set, failing the plan if that leaves any gap.
+func testAccSchedulesCoverageConfig(name, username string, weekends bool) string {
+	weekendRestriction := ""
+	if weekends {
+		weekendRestriction = `
+    restrictions {
+      duration          = 172800
+      start_day_of_week = 6
+      start_time_of_day = "00:00:00"
+    }`
+	}
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_schedules" "test" {
+  name      = %[1]q
+  time_zone = "UTC"
+  team_id   = zenduty_teams.test.id
+
+  layers {
+    name                = "Weekly"
+    shift_length        = 604800
+    rotation_start_time = "2020-01-06 00:00"
+    users               = [%[2]q]
+    restriction_type    = 2
+
+    restrictions {
+      duration          = 432000
+      start_day_of_week = 1
+      start_time_of_day = "00:00:00"
+    }%[3]s
+  }
+
+  coverage_check {
+    horizon_days = 14
+    mode         = "error"
+  }
+}
+`, name, username, weekendRestriction)
+}
+
+func TestAccSchedules_layerIdentity(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	var id, layerID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_schedules"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchedulesLayersConfig(name, username, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_schedules.test", &id),
+					testAccCheckScheduleLayerID("zenduty_schedules.test", 0, &layerID),
+				),
+			},
+			{
+				// Inserting a layer before it keeps the existing layer.
+				Config: testAccSchedulesLayersConfig(name, username, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_schedules.test", &id),
+					resource.TestCheckResourceAttr("zenduty_schedules.test", "layers.#", "2"),
+					resource.TestCheckResourceAttr("zenduty_schedules.test", "layers.0.name", "Nights"),
+					resource.TestCheckResourceAttrSet("zenduty_schedules.test", "layers.0.unique_id"),
+					resource.TestCheckResourceAttr("zenduty_schedules.test", "layers.1.name", "Weekly"),
+					testAccCheckScheduleLayerID("zenduty_schedules.test", 1, &layerID),
+				),
+			},
+			{
+				ResourceName:      "zenduty_schedules.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_schedules.test", "team_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccCheckScheduleLayerID records the unique_id of the layer at index of
+// the schedule resourceName in id, or checks that it is still the same.
+func testAccCheckScheduleLayerID(resourceName string, index int, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+		layerID := rs.Primary.Attributes[fmt.Sprintf("layers.%d.unique_id", index)]
+		if layerID == "" {
+			return fmt.Errorf("%s: layer %d has no unique_id", resourceName, index)
+		} else if *id == "" {
+			*id = layerID
+		} else if layerID != *id {
+			return fmt.Errorf("%s: layer %d was replaced: unique_id changed from %s to %s", resourceName, index, *id, layerID)
+		}
+		return nil
+	}
+}
+
+// testAccSchedulesLayersConfig adds a schedule with a weekly layer to the
+// team of testAccTeamConfig, and a night layer before it if nights is set.
+func testAccSchedulesLayersConfig(name, username string, nights bool) string {
+	nightLayer := ""
+	if nights {
+		nightLayer = fmt.Sprintf(`
+  layers {
+    name                = "Nights"
+    shift_length        = 86400
+    rotation_start_time = "2030-01-07 21:00"
+    users               = [%q]
+  }
+`, username)
+	}
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_schedules" "test" {
+  name      = %[1]q
+  time_zone = "Asia/Kolkata"
+  team_id   = zenduty_teams.test.id
+%[3]s
+  layers {
+    name                = "Weekly"
+    shift_length        = 604800
+    rotation_start_time = "2030-01-07 09:00"
+    users               = [%[2]q]
+  }
+}
+`, name, username, nightLayer)
+}
+
+func TestMatchScheduleLayers(t *testing.T) {
+	old := []scheduleLayerKey{{"id-a", "A"}, {"id-b", "B"}, {"id-c", "C"}}
+	tests := []struct {
+		name   string
+		layers []scheduleLayerKey
+		want   []int
+	}{
+		{"unchanged", []scheduleLayerKey{{name: "A"}, {name: "B"}, {name: "C"}}, []int{0, 1, 2}},
+		{"inserted", []scheduleLayerKey{{name: "N"}, {name: "A"}, {name: "B"}, {name: "C"}}, []int{-1, 0, 1, 2}},
+		{"reordered", []scheduleLayerKey{{name: "C"}, {name: "A"}, {name: "B"}}, []int{2, 0, 1}},
+		{"removed", []scheduleLayerKey{{name: "A"}, {name: "C"}}, []int{0, 2}},
+		{"renamed", []scheduleLayerKey{{name: "A"}, {name: "B2"}, {name: "C"}}, []int{0, 1, 2}},
+		{"by unique_id", []scheduleLayerKey{{"id-c", "A"}, {"id-a", "C"}}, []int{2, 0}},
+	}
+	for _, tt := range tests {
+		if got := matchScheduleLayers(tt.layers, old); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: matches = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidateScheduleRestrictions(t *testing.T) {
+	layer := func(restrictionType int, durations ...int) interface{} {
+		restrictions := []interface{}{}
+		for _, duration := range durations {
+			restrictions = append(restrictions, map[string]interface{}{
+				"duration":          duration,
+				"start_day_of_week": 1,
+				"start_time_of_day": "09:00:00",
+			})
+		}
+		return map[string]interface{}{"restriction_type": restrictionType, "restrictions": restrictions}
+	}
+	cases := []struct {
+		name        string
+		layers      []interface{}
+		wantPath    string
+		wantMessage string
+	}{
+		{"no restrictions", []interface{}{layer(restrictionNone)}, "", ""},
+		{"daily", []interface{}{layer(restrictionDaily, 8*3600, 86399)}, "", ""},
+		{"weekly", []interface{}{layer(restrictionWeekly, 5*86400, 604799)}, "", ""},
+		{"restrictions without a type", []interface{}{layer(restrictionNone, 3600)}, "layers[0].restriction_type", "restrictions must be set"},
+		{"daily of a day", []interface{}{layer(restrictionDaily, 86400)}, "layers[0].restrictions[0].duration", "less than 86400"},
+		{"weekly of a week", []interface{}{layer(restrictionWeekly, 604800)}, "layers[0].restrictions[0].duration", "less than 604800"},
+		{"second layer", []interface{}{layer(restrictionNone), layer(restrictionDaily, 3600, 90000)}, "layers[1].restrictions[1].duration", "less than 86400"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceSchedules().Schema, map[string]interface{}{"layers": c.layers})
+			testCheckPathError(t, validateScheduleRestrictions(d), c.wantPath, c.wantMessage)
+		})
+	}
+}
@@ -2,12 +2,12 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -18,10 +18,12 @@ func resourceServices() *schema.Resource {
 		CreateContext: resourceCreateServices,
 		UpdateContext: resourceUpdateServices,
 		DeleteContext: resourceDeleteServices,
-		ReadContext:   wrapReadWith404(resourceReadServices),
+		ReadContext:   resourceReadServices,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourceServiceImporter,
 		},
+		CustomizeDiff: resourceServicesCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -74,7 +76,26 @@ func resourceServices() *schema.Resource {
 	}
 }
 
-func CreateServices(Ctx context.Context, d *schema.ResourceData, m interface{}) (*client.Services, error) {
+func resourceServicesCustomizeDiff(Ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	return validateServiceCollation(d)
+}
+
+// validateServiceCollation checks that a collation_time is set whenever
+// collation is enabled.
+func validateServiceCollation(d resourceGetter) error {
+	if !valueKnown(d, "collation") || !valueKnown(d, "collation_time") {
+		return nil
+	}
+	if d.Get("collation").(int) == 1 && d.Get("collation_time").(int) == 0 {
+		return cty.GetAttrPath("collation_time").NewErrorf("collation_time is required when collation is enabled")
+	}
+	return nil
+}
+
+func CreateServices(Ctx context.Context, d *schema.ResourceData, m interface{}) (*client.Services, diag.Diagnostics) {
+	if err := validateServiceCollation(d); err != nil {
+		return nil, validationDiags(err)
+	}
 	newService := &client.Services{}
 
 	if v, ok := d.GetOk("name"); ok {
@@ -106,30 +127,29 @@ func CreateServices(Ctx context.Context, d *schema.ResourceData, m interface{})
 	if v, ok := d.GetOk("team_priority"); ok {
 		newService.TeamPriority = v.(string)
 	}
-	if newService.Collation == 1 && newService.CollationTime == 0 {
-		return nil, fmt.Errorf("collation_time is required when collation is enabled")
-
-	}
 	return newService, nil
 }
 
 func resourceCreateServices(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 
 	newService, serviceErr := CreateServices(Ctx, d, m)
 	if serviceErr != nil {
-		return diag.FromErr(serviceErr)
+		return serviceErr
 	}
 
 	var diags diag.Diagnostics
 	service, err := apiclient.Services.CreateService(teamID, newService)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	d.SetId(service.UniqueID)
@@ -139,54 +159,62 @@ func resourceCreateServices(Ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceUpdateServices(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	id := d.Id()
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	newService, serviceErr := CreateServices(Ctx, d, m)
 	if serviceErr != nil {
-		return diag.FromErr(serviceErr)
-
+		return serviceErr
 	}
 
-	_, err := apiclient.Services.UpdateService(teamID, id, newService)
+	_, err = apiclient.Services.UpdateService(teamID, id, newService)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	return resourceReadServices(Ctx, d, m)
 }
 
 func resourceDeleteServices(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	id := d.Id()
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	var diags diag.Diagnostics
-	err := apiclient.Services.DeleteService(teamID, id)
+	err = apiclient.Services.DeleteService(teamID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return diags
 }
 
 func resourceReadServices(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	id := d.Id()
 	if emptyString(teamID) {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	var diags diag.Diagnostics
 	service, err := apiclient.Services.GetServicesByID(teamID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.Set("name", service.Name)
 	d.Set("escalation_policy", service.EscalationPolicy)
@@ -0,0 +1,98 @@
+package zenduty
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccServices_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_services"),
+		Steps: []resource.TestStep{
+			{
+				// The plan fails before anything is created.
+				Config:      testAccServicesConfig(name, username, "Created by acceptance tests") + testAccServicesCollatedConfig,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`collation_time is required when collation is enabled`),
+			},
+			{
+				Config: testAccServicesConfig(name, username, "Created by acceptance tests"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_services.test", &id),
+					resource.TestCheckResourceAttr("zenduty_services.test", "name", name),
+					resource.TestCheckResourceAttrPair("zenduty_services.test", "escalation_policy", "zenduty_esp.test", "id"),
+				),
+			},
+			{
+				Config: testAccServicesConfig(name, username, "Updated by acceptance tests"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_services.test", &id),
+					resource.TestCheckResourceAttr("zenduty_services.test", "name", name),
+					resource.TestCheckResourceAttr("zenduty_services.test", "summary", "Updated by acceptance tests"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_services.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_services.test", "team_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccServicesCollatedConfig adds a service to testAccServicesConfig that
+// enables collation without a collation_time.
+const testAccServicesCollatedConfig = `
+resource "zenduty_services" "collated" {
+  name              = "collated"
+  team_id           = zenduty_teams.test.id
+  escalation_policy = zenduty_esp.test.id
+  collation         = 1
+}
+`
+
+// testAccServicesConfig adds a service to the escalation policy of
+// testAccEspConfig.
+func testAccServicesConfig(name, username, summary string) string {
+	return testAccEspConfig(name, username, 0) + fmt.Sprintf(`
+resource "zenduty_services" "test" {
+  name              = %q
+  summary           = %q
+  team_id           = zenduty_teams.test.id
+  escalation_policy = zenduty_esp.test.id
+}
+`, name, summary)
+}
+
+func TestValidateServiceCollation(t *testing.T) {
+	cases := []struct {
+		name          string
+		collation     int
+		collationTime int
+		wantPath      string
+	}{
+		{"disabled", 0, 0, ""},
+		{"disabled with a time", 0, 300, ""},
+		{"enabled with a time", 1, 300, ""},
+		{"enabled without a time", 1, 0, "collation_time"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceServices().Schema, map[string]interface{}{
+				"collation":      c.collation,
+				"collation_time": c.collationTime,
+			})
+			testCheckPathError(t, validateServiceCollation(d), c.wantPath, "collation_time is required when collation is enabled")
+		})
+	}
+}
@@ -2,13 +2,13 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"strings"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -19,7 +19,8 @@ func resourceSLA() *schema.Resource {
 		CreateContext: resourceCreateSLA,
 		UpdateContext: resourceUpdateSLA,
 		DeleteContext: resourceDeleteSLA,
-		ReadContext:   wrapReadWith404(resourceReadSLA),
+		ReadContext:   resourceReadSLA,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourceSLAImporter,
 		},
@@ -99,7 +100,7 @@ func CreateSLA(Ctx context.Context, d *schema.ResourceData, m interface{}) (*cli
 	if v, ok := d.GetOk("description"); ok {
 		newSLA.Description = v.(string)
 		if emptyString(newSLA.Description) {
-			return nil, diag.FromErr(errors.New("description is empty"))
+			return nil, attributeDiag(cty.GetAttrPath("description"), "description is empty")
 		}
 	}
 	if v, ok := d.GetOk("acknowledge_time"); ok {
@@ -174,11 +175,14 @@ func flattenResponderUser(responders []client.ResponderUser) []map[string]interf
 }
 
 func resourceCreateSLA(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	var teamID string
 	if v, ok := d.GetOk("team_id"); ok {
 		if emptyString(v.(string)) {
-			return diag.FromErr(errors.New("team_id must not be empty"))
+			return attributeDiag(cty.GetAttrPath("team_id"), "team_id must not be empty")
 		}
 		teamID = v.(string)
 	}
@@ -192,7 +196,7 @@ func resourceCreateSLA(Ctx context.Context, d *schema.ResourceData, m interface{
 	sla, err := apiclient.Sla.CreateSLA(teamID, newSLA)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	for i, escalation := range sla.Escalations {
@@ -209,11 +213,14 @@ func resourceCreateSLA(Ctx context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceUpdateSLA(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	var teamID string
 	if v, ok := d.GetOk("team_id"); ok {
 		if emptyString(v.(string)) {
-			return diag.FromErr(errors.New("team_id must not be empty"))
+			return attributeDiag(cty.GetAttrPath("team_id"), "team_id must not be empty")
 		}
 		teamID = v.(string)
 	}
@@ -228,7 +235,7 @@ func resourceUpdateSLA(Ctx context.Context, d *schema.ResourceData, m interface{
 	sla, err := apiclient.Sla.UpdateSLAByID(teamID, id, newSLA)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	if err := d.Set("escalations", flattenEscalation(sla.Escalations)); err != nil {
@@ -238,12 +245,15 @@ func resourceUpdateSLA(Ctx context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceDeleteSLA(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	var teamID string
 	if v, ok := d.GetOk("team_id"); ok {
 		if emptyString(v.(string)) {
-			return diag.FromErr(errors.New("team_id must not be empty"))
+			return attributeDiag(cty.GetAttrPath("team_id"), "team_id must not be empty")
 		}
 		teamID = v.(string)
 	}
@@ -251,15 +261,18 @@ func resourceDeleteSLA(Ctx context.Context, d *schema.ResourceData, m interface{
 	id := d.Id()
 
 	var diags diag.Diagnostics
-	err := apiclient.Sla.DeleteSLAByID(teamID, id)
+	err = apiclient.Sla.DeleteSLAByID(teamID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return diags
 }
 
 func resourceReadSLA(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	if v, ok := d.GetOk("team_id"); ok {
@@ -268,12 +281,12 @@ func resourceReadSLA(Ctx context.Context, d *schema.ResourceData, m interface{})
 
 	id := d.Id()
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required "))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	var diags diag.Diagnostics
 	sla, err := apiclient.Sla.GetSLAByID(teamID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.Set("name", sla.Name)
 	d.Set("description", sla.Description)
@@ -0,0 +1,64 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSLA_basic(t *testing.T) {
+	name := testAccName()
+	username := testAccUsername(t)
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_sla"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSLAConfig(name, username, 3600),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_sla.test", &id),
+					resource.TestCheckResourceAttr("zenduty_sla.test", "escalations.#", "1"),
+					resource.TestCheckResourceAttr("zenduty_sla.test", "escalations.0.responders.0.user", username),
+				),
+			},
+			{
+				Config: testAccSLAConfig(name, username, 7200),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_sla.test", &id),
+					resource.TestCheckResourceAttr("zenduty_sla.test", "resolve_time", "7200"),
+					resource.TestCheckResourceAttr("zenduty_sla.test", "acknowledge_time", "900"),
+					resource.TestCheckResourceAttr("zenduty_sla.test", "escalations.0.time", "300"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_sla.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_sla.test", "team_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSLAConfig(name, username string, resolveTime int) string {
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_sla" "test" {
+  name             = %[1]q
+  description      = "Created by acceptance tests"
+  team_id          = zenduty_teams.test.id
+  acknowledge_time = 900
+  resolve_time     = %[3]d
+
+  escalations {
+    time = 300
+    type = 1
+    responders {
+      user = %[2]q
+    }
+  }
+}
+`, name, username, resolveTime)
+}
@@ -2,11 +2,11 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -16,7 +16,8 @@ func resourceTags() *schema.Resource {
 		CreateContext: resourceCreateTags,
 		UpdateContext: resourceUpdateTags,
 		DeleteContext: resourceDeleteTags,
-		ReadContext:   wrapReadWith404(resourceReadTag),
+		ReadContext:   resourceReadTag,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourceTagImporter,
 		},
@@ -45,10 +46,10 @@ func validateTags(Ctx context.Context, d *schema.ResourceData, m interface{}) (*
 	team := d.Get("team_id").(string)
 	newTag := &client.Tag{}
 	if !IsValidUUID(team) {
-		return nil, diag.FromErr(errors.New("team_id must be a valid UUID"))
+		return nil, attributeDiag(cty.GetAttrPath("team_id"), "team_id must be a valid UUID")
 	}
 	if color != "" && !checkList(color, []string{"magenta", "red", "volcano", "orange", "gold", "lime", "green", "cyan", "blue", "geekblue", "purple"}) {
-		return nil, diag.FromErr(errors.New("color must be one of the following: magenta, red, volcano, orange, gold, lime, green, cyan, blue, geekblue, purple"))
+		return nil, attributeDiag(cty.GetAttrPath("color"), "color must be one of the following: magenta, red, volcano, orange, gold, lime, green, cyan, blue, geekblue, purple")
 	}
 
 	newTag.Name = name
@@ -59,14 +60,17 @@ func validateTags(Ctx context.Context, d *schema.ResourceData, m interface{}) (*
 
 func resourceCreateTags(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	team := d.Get("team_id").(string)
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	newtag, validationerr := validateTags(ctx, d, m)
 	if validationerr != nil {
 		return validationerr
 	}
 	tag, err := apiclient.Tags.CreateTag(team, newtag)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(tag.UniqueID)
 	return nil
@@ -74,14 +78,17 @@ func resourceCreateTags(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceUpdateTags(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	team := d.Get("team_id").(string)
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	newtag, validationerr := validateTags(ctx, d, m)
 	if validationerr != nil {
 		return validationerr
 	}
 	tag, err := apiclient.Tags.UpdateTag(team, d.Id(), newtag)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(tag.UniqueID)
 	return nil
@@ -89,21 +96,27 @@ func resourceUpdateTags(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceDeleteTags(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	team := d.Get("team_id").(string)
-	apiclient, _ := m.(*Config).Client()
-
-	err := apiclient.Tags.DeleteTag(team, d.Id())
+	apiclient, err := m.(*Meta).Client(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	err = apiclient.Tags.DeleteTag(team, d.Id())
+	if err != nil {
+		return handleDeleteError(d, err)
+	}
 	return nil
 }
 
 func resourceReadTag(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	team := d.Get("team_id").(string)
 	tag, err := apiclient.Tags.GetTagID(team, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.SetId(tag.UniqueID)
 	d.Set("name", tag.Name)
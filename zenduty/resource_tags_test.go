@@ -0,0 +1,51 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTags_basic(t *testing.T) {
+	name := testAccName()
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_tags"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTagsConfig(name, "red"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_tags.test", &id),
+					resource.TestCheckResourceAttr("zenduty_tags.test", "name", name),
+				),
+			},
+			{
+				Config: testAccTagsConfig(name, "green"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_tags.test", &id),
+					resource.TestCheckResourceAttr("zenduty_tags.test", "name", name),
+					resource.TestCheckResourceAttr("zenduty_tags.test", "color", "green"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_tags.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_tags.test", "team_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccTagsConfig(name, color string) string {
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_tags" "test" {
+  team_id = zenduty_teams.test.id
+  name    = %q
+  color   = %q
+}
+`, name, color)
+}
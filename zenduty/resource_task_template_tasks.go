@@ -2,12 +2,12 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -19,7 +19,8 @@ func resourceTaskTemplateTaskTasks() *schema.Resource {
 		CreateContext: resourceCreateTaskTemplateTaskTasks,
 		UpdateContext: resourceUpdateTaskTemplateTaskTasks,
 		DeleteContext: resourceDeleteTaskTemplateTaskTasks,
-		ReadContext:   wrapReadWith404(resourceReadTaskTemplateTaskTasks),
+		ReadContext:   resourceReadTaskTemplateTaskTasks,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourceTaskTemplateTaskTasksImporter,
 		},
@@ -98,11 +99,14 @@ func CreateTaskTemplateTask(Ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceCreateTaskTemplateTaskTasks(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 
 	task, createErr := CreateTaskTemplateTask(Ctx, d, m)
@@ -113,7 +117,7 @@ func resourceCreateTaskTemplateTaskTasks(Ctx context.Context, d *schema.Resource
 	var diags diag.Diagnostics
 	incidenttask, err := apiclient.TaskTemplate.CreateTaskTemplateTask(teamID, task.TaskTemplate, task)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	d.SetId(incidenttask.UniqueID)
@@ -122,12 +126,15 @@ func resourceCreateTaskTemplateTaskTasks(Ctx context.Context, d *schema.Resource
 }
 
 func resourceUpdateTaskTemplateTaskTasks(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	id := d.Id()
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	task, createErr := CreateTaskTemplateTask(Ctx, d, m)
 	if createErr != nil {
@@ -135,45 +142,51 @@ func resourceUpdateTaskTemplateTaskTasks(Ctx context.Context, d *schema.Resource
 
 	}
 
-	_, err := apiclient.TaskTemplate.UpdateTaskTemplateTaskByID(teamID, task.TaskTemplate, id, task)
+	_, err = apiclient.TaskTemplate.UpdateTaskTemplateTaskByID(teamID, task.TaskTemplate, id, task)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	return resourceReadTaskTemplateTaskTasks(Ctx, d, m)
 }
 
 func resourceDeleteTaskTemplateTaskTasks(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	task_id := d.Get("task_template_id").(string)
 
 	id := d.Id()
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 
 	var diags diag.Diagnostics
-	err := apiclient.TaskTemplate.DeleteTaskTemplateTaskByID(teamID, task_id, id)
+	err = apiclient.TaskTemplate.DeleteTaskTemplateTaskByID(teamID, task_id, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return diags
 }
 
 func resourceReadTaskTemplateTaskTasks(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	task_id := d.Get("task_template_id").(string)
 	id := d.Id()
 	if emptyString(teamID) {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	var diags diag.Diagnostics
 	tasktemplatetask, err := apiclient.TaskTemplate.GetTaskTemplateTaskByID(teamID, task_id, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.Set("title", tasktemplatetask.Title)
 	d.Set("description", tasktemplatetask.Description)
@@ -0,0 +1,53 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTaskTemplateTasks_basic(t *testing.T) {
+	name := testAccName()
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_task_template_tasks"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTaskTemplateTasksConfig(name, 60),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_task_template_tasks.test", &id),
+					resource.TestCheckResourceAttrPair("zenduty_task_template_tasks.test", "task_template_id", "zenduty_task_templates.test", "id"),
+				),
+			},
+			{
+				Config: testAccTaskTemplateTasksConfig(name, 120),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_task_template_tasks.test", &id),
+					resource.TestCheckResourceAttr("zenduty_task_template_tasks.test", "title", name),
+					resource.TestCheckResourceAttr("zenduty_task_template_tasks.test", "due_in", "120"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_task_template_tasks.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_task_template_tasks.test", "team_id", "task_template_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccTaskTemplateTasksConfig(name string, dueIn int) string {
+	return testAccTaskTemplatesConfig(name, "Created by acceptance tests") + fmt.Sprintf(`
+resource "zenduty_task_template_tasks" "test" {
+  team_id          = zenduty_teams.test.id
+  task_template_id = zenduty_task_templates.test.id
+  title            = %q
+  description      = "Created by acceptance tests"
+  due_in           = %d
+}
+`, name, dueIn)
+}
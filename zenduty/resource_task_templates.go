@@ -2,12 +2,12 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,7 +18,8 @@ func resourceTaskTemplates() *schema.Resource {
 		CreateContext: resourceCreateTaskTemplates,
 		UpdateContext: resourceUpdateTaskTemplates,
 		DeleteContext: resourceDeleteTaskTemplates,
-		ReadContext:   wrapReadWith404(resourceReadTaskTemplates),
+		ReadContext:   resourceReadTaskTemplates,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: resourceTaskTemplatesImporter,
 		},
@@ -70,11 +71,14 @@ func CreateTaskTemplate(Ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceCreateTaskTemplates(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 
 	task, createErr := CreateTaskTemplate(Ctx, d, m)
@@ -85,7 +89,7 @@ func resourceCreateTaskTemplates(Ctx context.Context, d *schema.ResourceData, m
 	var diags diag.Diagnostics
 	incidenttask, err := apiclient.TaskTemplate.CreateTaskTemplate(teamID, task)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	d.SetId(incidenttask.UniqueID)
@@ -94,12 +98,15 @@ func resourceCreateTaskTemplates(Ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceUpdateTaskTemplates(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	id := d.Id()
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	task, createErr := CreateTaskTemplate(Ctx, d, m)
 	if createErr != nil {
@@ -107,41 +114,47 @@ func resourceUpdateTaskTemplates(Ctx context.Context, d *schema.ResourceData, m
 
 	}
 
-	_, err := apiclient.TaskTemplate.UpdateTaskTemplateByID(teamID, id, task)
+	_, err = apiclient.TaskTemplate.UpdateTaskTemplateByID(teamID, id, task)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	return resourceReadTaskTemplates(Ctx, d, m)
 }
 
 func resourceDeleteTaskTemplates(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	id := d.Id()
 	if teamID == "" {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	var diags diag.Diagnostics
-	err := apiclient.TaskTemplate.DeleteTaskTemplateByID(teamID, id)
+	err = apiclient.TaskTemplate.DeleteTaskTemplateByID(teamID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return diags
 }
 
 func resourceReadTaskTemplates(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	teamID := d.Get("team_id").(string)
 	id := d.Id()
 	if emptyString(teamID) {
-		return diag.FromErr(errors.New("team_id is required"))
+		return attributeDiag(cty.GetAttrPath("team_id"), "team_id is required")
 	}
 	var diags diag.Diagnostics
 	postincidenttask, err := apiclient.TaskTemplate.GetTaskTemplateByID(teamID, id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.Set("name", postincidenttask.Name)
 	d.Set("summary", postincidenttask.Summary)
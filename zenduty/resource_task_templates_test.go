@@ -0,0 +1,51 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTaskTemplates_basic(t *testing.T) {
+	name := testAccName()
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_task_templates"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTaskTemplatesConfig(name, "Created by acceptance tests"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_task_templates.test", &id),
+					resource.TestCheckResourceAttr("zenduty_task_templates.test", "name", name),
+				),
+			},
+			{
+				Config: testAccTaskTemplatesConfig(name, "Updated by acceptance tests"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_task_templates.test", &id),
+					resource.TestCheckResourceAttr("zenduty_task_templates.test", "name", name),
+					resource.TestCheckResourceAttr("zenduty_task_templates.test", "summary", "Updated by acceptance tests"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_task_templates.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc("zenduty_task_templates.test", "team_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccTaskTemplatesConfig(name, summary string) string {
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_task_templates" "test" {
+  team_id = zenduty_teams.test.id
+  name    = %q
+  summary = %q
+}
+`, name, summary)
+}
@@ -2,22 +2,21 @@ package zenduty
 
 import (
 	"context"
-	"time"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceTeam() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceTeamCreate,
-		ReadContext:   wrapReadWith404(resourceTeamRead),
+		ReadContext:   resourceTeamRead,
 		UpdateContext: resourceTeamUpdate,
 		DeleteContext: resourceTeamDelete,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -31,7 +30,10 @@ func resourceTeam() *schema.Resource {
 }
 
 func resourceTeamCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	newteam := &client.CreateTeams{}
 
@@ -40,32 +42,19 @@ func resourceTeamCreate(ctx context.Context, d *schema.ResourceData, m interface
 		newteam.Name = v.(string)
 
 	}
-	retryErr := resource.Retry(1*time.Minute, func() *resource.RetryError {
-
-		task, err := apiclient.Teams.CreateTeam(newteam)
-		if err != nil {
-			return resource.RetryableError(err)
-		} else if task != nil {
-			d.SetId(task.UniqueID)
-		}
-		return nil
-	})
-	if retryErr != nil {
-		time.Sleep(2 * time.Second)
-		return diag.FromErr(retryErr)
+	task, err := apiclient.Teams.CreateTeam(newteam)
+	if err != nil {
+		return apiErrorDiags(err)
 	}
+	d.SetId(task.UniqueID)
 	return diags
-
-	// task, err := apiclient.Teams.CreateTeam(newteam)
-	// if err != nil {
-	// 	return diag.FromErr(err)
-	// }
-	// d.SetId(task.UniqueID)
-	// return diags
 }
 
 func resourceTeamUpdate(Ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(Ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	newteam := &client.CreateTeams{}
 	id := d.Id()
@@ -75,49 +64,40 @@ func resourceTeamUpdate(Ctx context.Context, d *schema.ResourceData, m interface
 		newteam.Name = v.(string)
 
 	}
-	retryErr := resource.Retry(1*time.Minute, func() *resource.RetryError {
-
-		task, err := apiclient.Teams.UpdateTeam(id, newteam)
-		if err != nil {
-			return resource.RetryableError(err)
-		} else if task != nil {
-			d.SetId(task.UniqueID)
-		}
-		return nil
-	})
-	if retryErr != nil {
-		time.Sleep(2 * time.Second)
-		return diag.FromErr(retryErr)
+	_, err = apiclient.Teams.UpdateTeam(id, newteam)
+	if err != nil {
+		return apiErrorDiags(err)
 	}
-	// _, err := apiclient.Teams.UpdateTeam(id, newteam)
-	// if err != nil {
-	// 	return diag.FromErr(err)
-	// }
 	return diags
-
 }
 
 func resourceTeamDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	id := d.Id()
 	var diags diag.Diagnostics
-	err := apiclient.Teams.DeleteTeam(id)
+	err = apiclient.Teams.DeleteTeam(id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return diags
 }
 
 func resourceTeamRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	id := d.Id()
 	var diags diag.Diagnostics
 
 	t, err := apiclient.Teams.GetTeamByID(id)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleReadError(d, err)
 	}
 	d.Set("name", t.Name)
 
@@ -2,11 +2,10 @@ package zenduty
 
 import (
 	"context"
-	"errors"
-	"fmt"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,9 +14,10 @@ import (
 func resourceTeamLevelPermissions() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceCreateTeamLeveLPermissions,
-		ReadContext:   wrapReadWith404(resourceReadTeamLeveLPermissions),
+		ReadContext:   resourceReadTeamLeveLPermissions,
 		UpdateContext: resourceUpdateTeamLeveLPermissions,
 		DeleteContext: resourceDeleteTeamLeveLPermissions,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -67,12 +67,13 @@ func validateTeamLeveLPermissionss(Ctx context.Context, d *schema.ResourceData,
 	newPermission := &client.TeamLevelPermissions{}
 	team_id := d.Get("team_id").(string)
 	newPermission.UniqueID = team_id
-	for _, permission := range permissions {
+	for i, permission := range permissions {
+		path := cty.GetAttrPath("permissions").IndexInt(i)
 		if permission.(string) == "" {
-			return nil, diag.FromErr(errors.New("permission must not be empty"))
+			return nil, attributeDiag(path, "permission must not be empty")
 		}
 		if !checkList(permission.(string), permissionsList) {
-			return nil, diag.FromErr(fmt.Errorf("invalid permission received %s", permission.(string)))
+			return nil, attributeDiagf(path, "invalid permission received %s", permission.(string))
 		}
 		newPermission.Permissions = append(newPermission.Permissions, permission.(string))
 	}
@@ -81,51 +82,63 @@ func validateTeamLeveLPermissionss(Ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceCreateTeamLeveLPermissions(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	newPermissions, validationerr := validateTeamLeveLPermissionss(ctx, d, m)
 	if validationerr != nil {
 		return validationerr
 	}
 	updatedPermission, err := apiclient.Teams.UpdateTeamLevelPermissions(newPermissions.UniqueID, newPermissions)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(updatedPermission.UniqueID)
 	return nil
 }
 
 func resourceUpdateTeamLeveLPermissions(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	newPermissions, validationerr := validateTeamLeveLPermissionss(ctx, d, m)
 	if validationerr != nil {
 		return validationerr
 	}
 	updatedPermission, err := apiclient.Teams.UpdateTeamLevelPermissions(newPermissions.UniqueID, newPermissions)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(updatedPermission.UniqueID)
 	return nil
 }
 
 func resourceDeleteTeamLeveLPermissions(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	newPermission := &client.TeamLevelPermissions{}
 	newPermission.Permissions = []string{}
 
-	_, err := apiclient.Teams.UpdateTeamLevelPermissions(d.Id(), newPermission)
+	_, err = apiclient.Teams.UpdateTeamLevelPermissions(d.Id(), newPermission)
 	if err != nil {
-		return diag.FromErr(err)
+		return handleDeleteError(d, err)
 	}
 	return nil
 }
 
 func resourceReadTeamLeveLPermissions(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiclient, _ := m.(*Config).Client()
-	teamPermissions, err := apiclient.Teams.GetTeamLevelPermissions(d.Id())
+	apiclient, err := m.(*Meta).Client(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	teamPermissions, err := apiclient.Teams.GetTeamLevelPermissions(d.Id())
+	if err != nil {
+		return handleReadError(d, err)
+	}
 	d.SetId(teamPermissions.UniqueID)
 	d.Set("team_id", teamPermissions.UniqueID)
 	d.Set("permissions", flattenPermissions(teamPermissions.Permissions))
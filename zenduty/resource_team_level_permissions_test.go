@@ -0,0 +1,85 @@
+package zenduty
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccTeamPermissions_basic(t *testing.T) {
+	name := testAccName()
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTeamPermissionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamPermissionsConfig(name, `"incident_read"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_team_permissions.test", &id),
+					resource.TestCheckResourceAttrPair("zenduty_team_permissions.test", "id", "zenduty_teams.test", "id"),
+					resource.TestCheckResourceAttr("zenduty_team_permissions.test", "permissions.#", "1"),
+				),
+			},
+			{
+				Config: testAccTeamPermissionsConfig(name, `"incident_read", "schedule_read"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_team_permissions.test", &id),
+					resource.TestCheckResourceAttr("zenduty_team_permissions.test", "permissions.#", "2"),
+					resource.TestCheckResourceAttr("zenduty_team_permissions.test", "permissions.1", "schedule_read"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_team_permissions.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccCheckTeamPermissionsDestroy checks that the permissions were
+// cleared. Destroying zenduty_team_permissions leaves the team in place, so
+// the generic destroy check does not apply. The team itself is destroyed in
+// the same run; that it is gone is also accepted.
+func testAccCheckTeamPermissionsDestroy(s *terraform.State) error {
+	meta, err := testAccMeta()
+	if err != nil {
+		return err
+	}
+	apiclient, err := meta.Client(context.Background())
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "zenduty_team_permissions" {
+			continue
+		}
+		permissions, err := apiclient.Teams.GetTeamLevelPermissions(rs.Primary.ID)
+		if isNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if len(permissions.Permissions) != 0 {
+			return fmt.Errorf("team %s still has permissions %v", rs.Primary.ID, permissions.Permissions)
+		}
+	}
+	return nil
+}
+
+// testAccTeamPermissionsConfig grants permissions, a comma separated list of
+// quoted permission names, to the team of testAccTeamConfig.
+func testAccTeamPermissionsConfig(name, permissions string) string {
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_team_permissions" "test" {
+  team_id     = zenduty_teams.test.id
+  permissions = [%s]
+}
+`, permissions)
+}
@@ -0,0 +1,47 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTeam_basic(t *testing.T) {
+	name := testAccName()
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("zenduty_teams"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_teams.test", &id),
+					resource.TestCheckResourceAttr("zenduty_teams.test", "name", name),
+				),
+			},
+			{
+				Config: testAccTeamConfig(name + "-updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_teams.test", &id),
+					resource.TestCheckResourceAttr("zenduty_teams.test", "name", name+"-updated"),
+				),
+			},
+			{
+				ResourceName:      "zenduty_teams.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccTeamConfig(name string) string {
+	return fmt.Sprintf(`
+resource "zenduty_teams" "test" {
+  name = %q
+}
+`, name)
+}
@@ -2,9 +2,9 @@ package zenduty
 
 import (
 	"context"
-	"errors"
 
 	"github.com/Zenduty/zenduty-go-sdk/client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -13,9 +13,10 @@ import (
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceCreateUser,
-		ReadContext:   wrapReadWith404(resourceUserRead),
+		ReadContext:   resourceUserRead,
 		UpdateContext: resourceUpdateUser,
 		DeleteContext: resourceDeleteUser,
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -55,19 +56,22 @@ func resourceCreateUser(ctx context.Context, d *schema.ResourceData, m interface
 	firstName := d.Get("first_name").(string)
 	lastName := d.Get("last_name").(string)
 	if emptyString(team) {
-		return diag.FromErr(errors.New("team is required"))
+		return attributeDiag(cty.GetAttrPath("team"), "team is required")
 	}
 	if emptyString(lastName) {
-		return diag.FromErr(errors.New("last_name is required"))
+		return attributeDiag(cty.GetAttrPath("last_name"), "last_name is required")
 	}
 	email := d.Get("email").(string)
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	newUser := &client.UserObj{FirstName: firstName, LastName: lastName, Email: email, Role: 3}
 	newUserobj := &client.CreateUser{Team: team, User: *newUser}
 
 	user, err := apiclient.Users.CreateUser(newUserobj)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(user.User.Username)
 	d.Set("role", 3)
@@ -80,13 +84,16 @@ func resourceUpdateUser(ctx context.Context, d *schema.ResourceData, m interface
 	firstName := d.Get("first_name").(string)
 	lastName := d.Get("last_name").(string)
 	email := d.Get("email").(string)
-	apiclient, _ := m.(*Config).Client()
+	apiclient, err := m.(*Meta).Client(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	newUser := &client.UserObj{FirstName: firstName, LastName: lastName, Email: email, Role: role}
 
 	user, err := apiclient.Users.UpdateUser(d.Id(), newUser)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 	d.SetId(user.User.Username)
 	d.Set("role", user.Role)
@@ -98,11 +105,14 @@ func resourceUpdateUser(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-	apiclient, _ := m.(*Config).Client()
-	user, err := apiclient.Users.GetUser(d.Id())
+	apiclient, err := m.(*Meta).Client(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	user, err := apiclient.Users.GetUser(d.Id())
+	if err != nil {
+		return handleReadError(d, err)
+	}
 	d.SetId(user.User.Username)
 	d.Set("role", user.Role)
 	d.Set("first_name", user.User.FirstName)
@@ -0,0 +1,55 @@
+package zenduty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Deleting zenduty_user only removes it from the state, so the test has no
+// destroy check.
+func TestAccUser_basic(t *testing.T) {
+	name := testAccName()
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserConfig(name, "Test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_user.test", &id),
+					resource.TestCheckResourceAttr("zenduty_user.test", "first_name", "Test"),
+					resource.TestCheckResourceAttr("zenduty_user.test", "role", "3"),
+				),
+			},
+			{
+				Config: testAccUserConfig(name, "Updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceID("zenduty_user.test", &id),
+					resource.TestCheckResourceAttr("zenduty_user.test", "first_name", "Updated"),
+					resource.TestCheckResourceAttr("zenduty_user.test", "last_name", "User"),
+					resource.TestCheckResourceAttr("zenduty_user.test", "email", name+"@example.com"),
+				),
+			},
+			{
+				ResourceName:            "zenduty_user.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"team"},
+			},
+		},
+	})
+}
+
+func testAccUserConfig(name, firstName string) string {
+	return testAccTeamConfig(name) + fmt.Sprintf(`
+resource "zenduty_user" "test" {
+  team       = zenduty_teams.test.id
+  first_name = %q
+  last_name  = "User"
+  email      = "%s@example.com"
+}
+`, firstName, name)
+}
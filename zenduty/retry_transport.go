@@ -0,0 +1,137 @@
+package zenduty
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetries   = 5
+	defaultRetryMaxWait = 30 * time.Second
+	retryBaseWait       = 1 * time.Second
+)
+
+// retryTransport retries requests that failed because of rate limiting or a
+// transient server error. Requests that are not idempotent are only retried
+// when the API rejected them before doing any work (429), so a retry can never
+// create the same object twice.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := bufferRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		r := req.Clone(req.Context())
+		if body != nil {
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err := t.base.RoundTrip(r)
+		if attempt >= t.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := retryDelay(attempt, resp, t.maxWait)
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+			log.Printf("[WARN] zenduty %s %s returned %s, retrying in %s (attempt %d of %d)", req.Method, req.URL.Path, resp.Status, wait, attempt+1, t.maxRetries)
+		} else {
+			log.Printf("[WARN] zenduty %s %s failed: %s, retrying in %s (attempt %d of %d)", req.Method, req.URL.Path, err, wait, attempt+1, t.maxRetries)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// bufferRequestBody reads the request body into memory so it can be sent
+// again on every attempt.
+func bufferRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return ioutil.ReadAll(req.Body)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return isIdempotent(req.Method)
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode == http.StatusNotImplemented:
+		return false
+	case resp.StatusCode >= 500:
+		return isIdempotent(req.Method)
+	}
+	return false
+}
+
+// retryDelay honors the Retry-After header when the API sends one and falls
+// back to exponential backoff with jitter. The result never exceeds maxWait.
+func retryDelay(attempt int, resp *http.Response, maxWait time.Duration) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > maxWait {
+				return maxWait
+			}
+			return wait
+		}
+	}
+
+	backoff := float64(retryBaseWait) * math.Pow(2, float64(attempt))
+	if backoff > float64(maxWait) {
+		backoff = float64(maxWait)
+	}
+	return time.Duration(backoff/2 + rand.Float64()*backoff/2)
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
@@ -0,0 +1,197 @@
+package zenduty
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// roundTripperFunc lets a test stand in for the transport below the one it
+// tests.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// scriptedServer answers the requests it receives with statuses, one after
+// the other, and 200 once they run out. It records the body of every request.
+type scriptedServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	bodies   []string
+}
+
+func newScriptedServer(t *testing.T, statuses ...int) *scriptedServer {
+	s := &scriptedServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		s.mu.Lock()
+		s.bodies = append(s.bodies, string(body))
+		status := http.StatusOK
+		if len(s.statuses) > 0 {
+			status, s.statuses = s.statuses[0], s.statuses[1:]
+		}
+		s.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestRetryTransport(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		statuses     []int
+		wantStatus   int
+		wantAttempts int
+	}{
+		{"success", http.MethodGet, nil, http.StatusOK, 1},
+		{"server errors", http.MethodGet, []int{500, 502, 503}, http.StatusOK, 4},
+		{"rate limited", http.MethodGet, []int{429}, http.StatusOK, 2},
+		{"rate limited POST", http.MethodPost, []int{429, 429}, http.StatusOK, 3},
+		{"server error on POST", http.MethodPost, []int{503}, http.StatusServiceUnavailable, 1},
+		{"server error on PATCH", http.MethodPatch, []int{500}, http.StatusInternalServerError, 1},
+		{"server error on PUT", http.MethodPut, []int{500}, http.StatusOK, 2},
+		{"server error on DELETE", http.MethodDelete, []int{504}, http.StatusOK, 2},
+		{"not implemented", http.MethodGet, []int{501}, http.StatusNotImplemented, 1},
+		{"client error", http.MethodGet, []int{400}, http.StatusBadRequest, 1},
+		{"not found", http.MethodGet, []int{404}, http.StatusNotFound, 1},
+		{"out of retries", http.MethodGet, []int{500, 500, 500, 500}, http.StatusInternalServerError, 4},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := newScriptedServer(t, c.statuses...)
+			transport := &retryTransport{base: http.DefaultTransport, maxRetries: 3, maxWait: time.Millisecond}
+
+			req, err := http.NewRequest(c.method, server.URL, strings.NewReader(`{"name": "test"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != c.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, c.wantStatus)
+			}
+			if len(server.bodies) != c.wantAttempts {
+				t.Fatalf("%d attempts, want %d", len(server.bodies), c.wantAttempts)
+			}
+			for i, body := range server.bodies {
+				if body != `{"name": "test"}` {
+					t.Errorf("body of attempt %d = %q, want it sent again unchanged", i+1, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransport_networkError(t *testing.T) {
+	for method, wantAttempts := range map[string]int{http.MethodGet: 3, http.MethodPost: 1} {
+		attempts := 0
+		transport := &retryTransport{
+			base: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+				attempts++
+				return nil, errors.New("connection reset by peer")
+			}),
+			maxRetries: 2,
+			maxWait:    time.Millisecond,
+		}
+		req, _ := http.NewRequest(method, "https://www.zenduty.com/api/account/teams/", nil)
+		if _, err := transport.RoundTrip(req); err == nil {
+			t.Errorf("%s: no error", method)
+		}
+		if attempts != wantAttempts {
+			t.Errorf("%s: %d attempts, want %d", method, attempts, wantAttempts)
+		}
+	}
+}
+
+func TestRetryTransport_canceledWhileWaiting(t *testing.T) {
+	attempts := 0
+	transport := &retryTransport{
+		base: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			attempts++
+			header := http.Header{"Retry-After": {"60"}}
+			return &http.Response{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests", Header: header, Body: http.NoBody}, nil
+		}),
+		maxRetries: 5,
+		maxWait:    time.Minute,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://www.zenduty.com/api/account/teams/", nil)
+
+	start := time.Now()
+	_, err := transport.RoundTrip(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("returned after %s, want it to stop waiting when the context is done", elapsed)
+	}
+	if attempts != 1 {
+		t.Errorf("%d attempts, want 1", attempts)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	const maxWait = 30 * time.Second
+	withRetryAfter := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": {value}}}
+	}
+	cases := []struct {
+		name     string
+		attempt  int
+		resp     *http.Response
+		min, max time.Duration
+	}{
+		{"first backoff", 0, nil, retryBaseWait / 2, retryBaseWait},
+		{"fourth backoff", 3, nil, 4 * time.Second, 8 * time.Second},
+		{"backoff at the limit", 10, nil, maxWait / 2, maxWait},
+		{"Retry-After seconds", 0, withRetryAfter("7"), 7 * time.Second, 7 * time.Second},
+		{"Retry-After past the limit", 0, withRetryAfter("3600"), maxWait, maxWait},
+		{"Retry-After date", 0, withRetryAfter(time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)), 8 * time.Second, 10 * time.Second},
+		{"invalid Retry-After", 0, withRetryAfter("soon"), retryBaseWait / 2, retryBaseWait},
+	}
+	for _, c := range cases {
+		for i := 0; i < 20; i++ {
+			if got := retryDelay(c.attempt, c.resp, maxWait); got < c.min || got > c.max {
+				t.Errorf("%s: retryDelay() = %s, want between %s and %s", c.name, got, c.min, c.max)
+				break
+			}
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	cases := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"0", 0, true},
+		{"120", 2 * time.Minute, true},
+		{"-5", 0, false},
+		{"1.5", 0, false},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 0, true},
+		{"tomorrow", 0, false},
+	}
+	for _, c := range cases {
+		got, ok := parseRetryAfter(c.value)
+		if got != c.want || ok != c.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %s, %t, want %s, %t", c.value, got, ok, c.want, c.wantOK)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package zenduty
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icalTimeFormat is the format of UTC date-times in iCalendar.
+const icalTimeFormat = "20060102T150405Z"
+
+// icalCalendar is an iCalendar (RFC 5545) calendar with one event per shift
+// of a schedule.
+type icalCalendar struct {
+	scheduleID string
+	name       string
+	timeZone   string
+	// stamp is the DTSTAMP of every event. It comes from the schedule
+	// rather than the time of rendering, so that the same shifts always
+	// render the same way.
+	stamp  time.Time
+	shifts []oncallShift
+}
+
+// String renders the calendar. Times are in UTC, so that no VTIMEZONE is
+// needed; calendar applications show them in the time zone of the reader.
+func (c icalCalendar) String() string {
+	var b strings.Builder
+	line := func(name, value string) {
+		b.WriteString(icalFold(name + ":" + value))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//Zenduty//terraform-provider-zenduty//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", icalText(c.name))
+	line("X-WR-TIMEZONE", icalText(c.timeZone))
+	for _, shift := range c.shifts {
+		kind := "layer"
+		if shift.override {
+			kind = "override"
+		}
+		line("BEGIN", "VEVENT")
+		// The UID uses when the shift began, not when the calendar cuts
+		// it, so an event keeps its UID as the calendar moves on.
+		line("UID", dataSourceID(c.scheduleID, kind, shift.layer, shift.user, shift.began.UTC().Format(time.RFC3339))+"@zenduty.com")
+		line("DTSTAMP", c.stamp.UTC().Format(icalTimeFormat))
+		line("DTSTART", shift.start.UTC().Format(icalTimeFormat))
+		line("DTEND", shift.end.UTC().Format(icalTimeFormat))
+		line("SUMMARY", icalText(fmt.Sprintf("On call: %s", shift.user)))
+		line("DESCRIPTION", icalText(fmt.Sprintf("%s, %s %s", c.name, kind, shift.layer)))
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return b.String()
+}
+
+// icalText escapes s for use as an iCalendar TEXT value.
+func icalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// icalFold folds a content line into lines of at most 75 octets, without
+// splitting UTF-8 sequences. Continuation lines start with a space.
+func icalFold(line string) string {
+	var b strings.Builder
+	limit := 75
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+		b.WriteString(line[:i])
+		b.WriteString("\r\n ")
+		line = line[i:]
+		limit = 74
+	}
+	b.WriteString(line)
+	return b.String()
+}
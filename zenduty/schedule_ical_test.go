@@ -0,0 +1,60 @@
+package zenduty
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestICalCalendar(t *testing.T) {
+	c := icalCalendar{
+		scheduleID: "6c0eb6d6-d7a1-4f5e-9b3c-2c1e1a4f0b7d",
+		name:       "Payments, primary",
+		timeZone:   "Asia/Kolkata",
+		stamp:      mustTime(t, "2030-01-07T00:00:00Z"),
+		shifts: []oncallShift{
+			// Cut to fit the calendar.
+			{layer: "Business hours", user: "alice", start: mustTime(t, "2030-01-07T03:30:00Z"), end: mustTime(t, "2030-01-07T12:30:00Z"), began: mustTime(t, "2030-01-06T03:30:00Z")},
+			{layer: "Swap", user: "bob", start: mustTime(t, "2030-01-08T03:30:00Z"), end: mustTime(t, "2030-01-08T06:30:00Z"), override: true, began: mustTime(t, "2030-01-08T03:30:00Z")},
+		},
+	}
+	ical := c.String()
+
+	if !strings.HasPrefix(ical, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n") || !strings.HasSuffix(ical, "END:VCALENDAR\r\n") {
+		t.Errorf("calendar is not wrapped in VCALENDAR:\n%s", ical)
+	}
+	unfolded := strings.ReplaceAll(ical, "\r\n ", "")
+	for _, want := range []string{
+		"X-WR-CALNAME:Payments\\, primary\r\n",
+		"DTSTART:20300107T033000Z\r\nDTEND:20300107T123000Z\r\nSUMMARY:On call: alice\r\n",
+		"DESCRIPTION:Payments\\, primary\\, layer Business hours\r\n",
+		"DESCRIPTION:Payments\\, primary\\, override Swap\r\n",
+		"UID:" + dataSourceID(c.scheduleID, "layer", "Business hours", "alice", "2030-01-06T03:30:00Z") + "@zenduty.com\r\n",
+		"DTSTAMP:20300107T000000Z\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("calendar does not contain %q:\n%s", want, ical)
+		}
+	}
+	if n := strings.Count(ical, "BEGIN:VEVENT"); n != 2 {
+		t.Errorf("calendar has %d events, want 2", n)
+	}
+	if again := c.String(); again != ical {
+		t.Errorf("calendar renders differently the second time:\n%s", again)
+	}
+}
+
+func TestICalFold(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("é", 60)
+	folded := icalFold(line)
+	for _, l := range strings.Split(folded, "\r\n") {
+		if len(l) > 75 {
+			t.Errorf("line of %d octets: %q", len(l), l)
+		}
+	}
+	if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != line {
+		t.Errorf("unfolded line = %q, want %q", unfolded, line)
+	}
+	if short := "SUMMARY:On call"; icalFold(short) != short {
+		t.Errorf("short line was folded: %q", icalFold(short))
+	}
+}
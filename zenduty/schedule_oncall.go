@@ -0,0 +1,409 @@
+package zenduty
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Zenduty/zenduty-go-sdk/client"
+)
+
+// Restriction types of a schedule layer.
+const (
+	restrictionNone   = 0
+	restrictionDaily  = 1
+	restrictionWeekly = 2
+)
+
+// oncallSchedule is a schedule as returned by the API, in the form needed to
+// work out who is on call at a given time.
+//
+// Every layer has one user on call at a time, rotating through its users
+// every shift_length seconds from rotation_start_time until
+// rotation_end_time. Layers with restrictions are only on call inside them.
+// All layers are on call at once, except while an override is active: then
+// only the users of the active overrides are.
+type oncallSchedule struct {
+	location  *time.Location
+	layers    []oncallLayer
+	overrides []oncallOverride
+}
+
+type oncallLayer struct {
+	name        string
+	start       time.Time
+	end         time.Time // zero when the rotation does not end
+	shiftLength time.Duration
+	users       []string
+
+	restrictionType int
+	restrictions    []oncallRestriction
+}
+
+// oncallRestriction is a window in which a layer is on call, repeating every
+// day or every week.
+type oncallRestriction struct {
+	startDay  int           // 1 for Monday to 7 for Sunday, for weekly restrictions
+	startTime time.Duration // from midnight
+	duration  time.Duration
+}
+
+type oncallOverride struct {
+	name  string
+	start time.Time
+	end   time.Time
+	user  string
+}
+
+// newOncallSchedule builds an oncallSchedule from the fields of a schedule
+// read from the API, whose times are RFC 3339 timestamps in UTC.
+func newOncallSchedule(timeZone string, layers []client.Layers, overrides []client.Overrides) (*oncallSchedule, error) {
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, err
+	}
+	s := &oncallSchedule{location: loc}
+
+	for i, layer := range layers {
+		l := oncallLayer{
+			name:            layer.Name,
+			shiftLength:     time.Duration(layer.ShiftLength) * time.Second,
+			restrictionType: layer.RestrictionType,
+		}
+		if emptyString(l.name) {
+			l.name = fmt.Sprintf("Layer-%d", i+1)
+		}
+		if l.shiftLength <= 0 {
+			return nil, fmt.Errorf("layer %q has no shift length", l.name)
+		}
+		if l.start, err = time.Parse(time.RFC3339, layer.RotationStartTime); err != nil {
+			return nil, fmt.Errorf("layer %q has an invalid rotation_start_time: %w", l.name, err)
+		}
+		if layer.RotationEndTime != "" {
+			if l.end, err = time.Parse(time.RFC3339, layer.RotationEndTime); err != nil {
+				return nil, fmt.Errorf("layer %q has an invalid rotation_end_time: %w", l.name, err)
+			}
+		}
+		for _, user := range layer.Users {
+			l.users = append(l.users, user.User)
+		}
+		for _, restriction := range layer.Restrictions {
+			var h, m, sec int
+			if _, err := fmt.Sscanf(restriction.StartTimeOfDay, "%d:%d:%d", &h, &m, &sec); err != nil {
+				return nil, fmt.Errorf("layer %q has an invalid start_time_of_day %q", l.name, restriction.StartTimeOfDay)
+			}
+			l.restrictions = append(l.restrictions, oncallRestriction{
+				startDay:  restriction.StartDayOfWeek,
+				startTime: time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(sec)*time.Second,
+				duration:  time.Duration(restriction.Duration) * time.Second,
+			})
+		}
+		s.layers = append(s.layers, l)
+	}
+
+	for i, override := range overrides {
+		o := oncallOverride{name: override.Name, user: override.User}
+		if emptyString(o.name) {
+			o.name = fmt.Sprintf("Override-%d", i+1)
+		}
+		if o.start, err = time.Parse(time.RFC3339, override.StartTime); err != nil {
+			return nil, fmt.Errorf("override %q has an invalid start_time: %w", o.name, err)
+		}
+		if o.end, err = time.Parse(time.RFC3339, override.EndTime); err != nil {
+			return nil, fmt.Errorf("override %q has an invalid end_time: %w", o.name, err)
+		}
+		s.overrides = append(s.overrides, o)
+	}
+	return s, nil
+}
+
+// oncallLayerState is who a layer has on call at a time.
+type oncallLayerState struct {
+	name string
+	// user is empty when the layer has nobody on call.
+	user string
+	// since is when user went on call.
+	since time.Time
+	// until is when the layer may next change hands. It is zero when the
+	// layer stays as it is from then on.
+	until time.Time
+}
+
+// oncallState is who a schedule has on call at a time.
+type oncallState struct {
+	layers []oncallLayerState
+	// overrides are the overrides active at that time.
+	overrides []oncallOverride
+	// users are the users on call, in layer or override order and
+	// without duplicates.
+	users []string
+	// until is when the schedule may next change hands, zero when it
+	// stays as it is from then on.
+	until time.Time
+}
+
+// at returns who is on call at t.
+func (s *oncallSchedule) at(t time.Time) oncallState {
+	var state oncallState
+	for _, layer := range s.layers {
+		ls := layer.at(t, s.location)
+		state.layers = append(state.layers, ls)
+		state.until = earliest(state.until, ls.until)
+	}
+	for _, override := range s.overrides {
+		if t.Before(override.start) {
+			state.until = earliest(state.until, override.start)
+			continue
+		}
+		if t.Before(override.end) {
+			state.overrides = append(state.overrides, override)
+			state.until = earliest(state.until, override.end)
+			continue
+		}
+		// The layers went back on call when the override ended.
+		for i := range state.layers {
+			if state.layers[i].user != "" && override.end.After(state.layers[i].since) {
+				state.layers[i].since = override.end
+			}
+		}
+	}
+
+	if len(state.overrides) > 0 {
+		for _, override := range state.overrides {
+			state.users = appendUnique(state.users, override.user)
+		}
+	} else {
+		for _, ls := range state.layers {
+			if ls.user != "" {
+				state.users = appendUnique(state.users, ls.user)
+			}
+		}
+	}
+	return state
+}
+
+// at returns who the layer has on call at t.
+func (l oncallLayer) at(t time.Time, loc *time.Location) oncallLayerState {
+	state := oncallLayerState{name: l.name}
+	if len(l.users) == 0 {
+		return state
+	}
+	if t.Before(l.start) {
+		state.until = l.start
+		return state
+	}
+	if !l.end.IsZero() && !t.Before(l.end) {
+		return state
+	}
+
+	shift := t.Sub(l.start) / l.shiftLength
+	state.until = l.start.Add((shift + 1) * l.shiftLength)
+	if !l.end.IsZero() {
+		state.until = earliest(state.until, l.end)
+	}
+
+	inside := true
+	var window time.Time
+	if l.restrictionType != restrictionNone && len(l.restrictions) > 0 {
+		var edge time.Time
+		inside, edge, window = l.restricted(t, loc)
+		state.until = earliest(state.until, edge)
+	}
+	if inside {
+		state.user = l.users[int(shift)%len(l.users)]
+		// A user on call for several shifts in a row is on call from the
+		// first of them; after a whole round of the same user, from the
+		// start of the rotation.
+		first := shift
+		for first > 0 && l.users[int(first-1)%len(l.users)] == state.user {
+			first--
+			if shift-first == time.Duration(len(l.users)) {
+				first = 0
+			}
+		}
+		state.since = l.start.Add(first * l.shiftLength)
+		if window.After(state.since) {
+			state.since = window
+		}
+	}
+	return state
+}
+
+// restricted reports whether t falls inside one of the restrictions of the
+// layer, the next time after t at which a restriction starts or ends and,
+// when t is inside, when the restriction it is inside started.
+// Restrictions are laid out on the calendar of loc, so they keep their time
+// of day across daylight saving time changes.
+func (l oncallLayer) restricted(t time.Time, loc *time.Location) (bool, time.Time, time.Time) {
+	local := t.In(loc)
+	period := 1
+	// Midnight of the first day of the period t falls in.
+	first := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	if l.restrictionType == restrictionWeekly {
+		period = 7
+		first = first.AddDate(0, 0, -((int(local.Weekday()) + 6) % 7))
+	}
+
+	inside := false
+	var edge, since time.Time
+	// A restriction that started in the previous period can still be
+	// running, and the next edge can be in the following one.
+	for _, p := range []int{-1, 0, 1} {
+		for _, r := range l.restrictions {
+			day := 0
+			if l.restrictionType == restrictionWeekly {
+				day = r.startDay - 1
+			}
+			start := time.Date(first.Year(), first.Month(), first.Day()+p*period+day, 0, 0, int(r.startTime/time.Second), 0, loc)
+			end := start.Add(r.duration)
+			if !t.Before(start) && t.Before(end) {
+				inside = true
+				if since.IsZero() || start.Before(since) {
+					since = start
+				}
+			}
+			if start.After(t) {
+				edge = earliest(edge, start)
+			}
+			if end.After(t) {
+				edge = earliest(edge, end)
+			}
+		}
+	}
+	return inside, edge, since
+}
+
+// oncallShift is a stretch of time for which a user is on call, from a layer
+// or, when override is set, from an override.
+type oncallShift struct {
+	layer    string
+	user     string
+	start    time.Time
+	end      time.Time
+	override bool
+	// began is when the shift started before it was cut to fit between
+	// from and to, the same as start for the shifts that were not.
+	began time.Time
+}
+
+// shifts returns the shifts of the schedule between from and to, in order of
+// their start, cut to fit between them. Layers have no shifts while an
+// override is active.
+func (s *oncallSchedule) shifts(from, to time.Time) []oncallShift {
+	var shifts []oncallShift
+	// open holds the index in shifts of the shift each layer or override
+	// is on, to extend it while the same user stays on call.
+	open := make(map[string]int)
+	add := func(layer, user string, override bool, since, start, end time.Time) {
+		key := fmt.Sprintf("%t\x00%s\x00%s", override, layer, user)
+		if i, ok := open[key]; ok && shifts[i].end.Equal(start) {
+			shifts[i].end = end
+			return
+		}
+		began := start
+		if start.Equal(from) && since.Before(start) {
+			began = since
+		}
+		open[key] = len(shifts)
+		shifts = append(shifts, oncallShift{layer: layer, user: user, start: start, end: end, override: override, began: began})
+	}
+
+	for t := from; t.Before(to); {
+		state := s.at(t)
+		next := state.until
+		if next.IsZero() || next.After(to) {
+			next = to
+		}
+		if len(state.overrides) > 0 {
+			for _, override := range state.overrides {
+				add(override.name, override.user, true, override.start, t, next)
+			}
+		} else {
+			for _, layer := range state.layers {
+				if layer.user != "" {
+					add(layer.name, layer.user, false, layer.since, t, next)
+				}
+			}
+		}
+		t = next
+	}
+	return shifts
+}
+
+// oncallInterval is a stretch of time with layers layers on call at most.
+type oncallInterval struct {
+	start  time.Time
+	end    time.Time
+	layers int
+}
+
+// start returns the earliest start of the layers and overrides of s, or the
+// zero time when it has neither.
+func (s *oncallSchedule) start() time.Time {
+	var start time.Time
+	for _, layer := range s.layers {
+		if start.IsZero() || layer.start.Before(start) {
+			start = layer.start
+		}
+	}
+	for _, override := range s.overrides {
+		if start.IsZero() || override.start.Before(start) {
+			start = override.start
+		}
+	}
+	return start
+}
+
+// coverage returns the intervals between from and to with nobody on call,
+// and, when maxOverlap is positive, those with more than maxOverlap layers on
+// call at once. Overrides cover the intervals they are active in, whatever
+// the layers.
+func (s *oncallSchedule) coverage(from, to time.Time, maxOverlap int) (gaps, overlaps []oncallInterval) {
+	extend := func(intervals []oncallInterval, start, end time.Time, layers int) []oncallInterval {
+		if n := len(intervals); n > 0 && intervals[n-1].end.Equal(start) {
+			intervals[n-1].end = end
+			if layers > intervals[n-1].layers {
+				intervals[n-1].layers = layers
+			}
+			return intervals
+		}
+		return append(intervals, oncallInterval{start: start, end: end, layers: layers})
+	}
+
+	for t := from; t.Before(to); {
+		state := s.at(t)
+		next := state.until
+		if next.IsZero() || next.After(to) {
+			next = to
+		}
+		if len(state.overrides) == 0 {
+			layers := 0
+			for _, layer := range state.layers {
+				if layer.user != "" {
+					layers++
+				}
+			}
+			if layers == 0 {
+				gaps = extend(gaps, t, next, 0)
+			} else if maxOverlap > 0 && layers > maxOverlap {
+				overlaps = extend(overlaps, t, next, layers)
+			}
+		}
+		t = next
+	}
+	return gaps, overlaps
+}
+
+// earliest returns the earlier of a and b, ignoring zero times.
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
+func appendUnique(list []string, s string) []string {
+	if checkList(s, list) {
+		return list
+	}
+	return append(list, s)
+}
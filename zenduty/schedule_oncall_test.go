@@ -0,0 +1,284 @@
+package zenduty
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Zenduty/zenduty-go-sdk/client"
+)
+
+func mustTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	v, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func testOncallSchedule(t *testing.T, timeZone string, layers []client.Layers, overrides []client.Overrides) *oncallSchedule {
+	t.Helper()
+	s, err := newOncallSchedule(timeZone, layers, overrides)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestOncallScheduleAt(t *testing.T) {
+	users := []client.Users{{User: "alice"}, {User: "bob"}, {User: "carol"}}
+	daily := client.Layers{
+		Name:              "Daily",
+		ShiftLength:       86400,
+		RotationStartTime: "2030-01-07T00:00:00Z",
+		RotationEndTime:   "2030-02-01T00:00:00Z",
+		Users:             users,
+	}
+	officeHours := client.Layers{
+		Name:              "Office hours",
+		ShiftLength:       7 * 86400,
+		RotationStartTime: "2030-01-06T18:30:00Z",
+		Users:             []client.Users{{User: "dave"}},
+		RestrictionType:   restrictionDaily,
+		Restrictions:      []client.Restrictions{{StartDayOfWeek: 7, StartTimeOfDay: "09:00:00", Duration: 8 * 3600}},
+	}
+	weekend := client.Layers{
+		Name:              "Weekend",
+		ShiftLength:       7 * 86400,
+		RotationStartTime: "2030-01-01T00:00:00Z",
+		Users:             []client.Users{{User: "erin"}},
+		RestrictionType:   restrictionWeekly,
+		Restrictions:      []client.Restrictions{{StartDayOfWeek: 6, StartTimeOfDay: "00:00:00", Duration: 2 * 86400}},
+	}
+	override := client.Overrides{
+		Name:      "Holiday",
+		StartTime: "2030-01-10T00:00:00Z",
+		EndTime:   "2030-01-11T00:00:00Z",
+		User:      "frank",
+	}
+	s := testOncallSchedule(t, "Asia/Kolkata", []client.Layers{daily, officeHours, weekend}, []client.Overrides{override})
+
+	tests := []struct {
+		at     string
+		layers []string
+		users  []string
+		until  string
+	}{
+		// Before the daily rotation starts, and outside office hours
+		// (09:00 to 17:00 in Kolkata, 03:30 to 11:30 UTC).
+		{"2030-01-06T19:00:00Z", []string{"", "", ""}, nil, "2030-01-07T00:00:00Z"},
+		{"2030-01-07T01:00:00Z", []string{"alice", "", ""}, []string{"alice"}, "2030-01-07T03:30:00Z"},
+		{"2030-01-08T04:00:00Z", []string{"bob", "dave", ""}, []string{"bob", "dave"}, "2030-01-08T11:30:00Z"},
+		{"2030-01-09T12:00:00Z", []string{"carol", "", ""}, []string{"carol"}, "2030-01-10T00:00:00Z"},
+		// The override replaces every layer.
+		{"2030-01-10T04:00:00Z", []string{"alice", "dave", ""}, []string{"frank"}, "2030-01-10T11:30:00Z"},
+		// Saturday 00:00 in Kolkata is Friday 18:30 UTC.
+		{"2030-01-11T18:30:00Z", []string{"bob", "", "erin"}, []string{"bob", "erin"}, "2030-01-12T00:00:00Z"},
+		{"2030-01-13T18:29:00Z", []string{"alice", "", "erin"}, []string{"alice", "erin"}, "2030-01-13T18:30:00Z"},
+		// After the daily rotation ends.
+		{"2030-02-01T00:00:00Z", []string{"", "", ""}, nil, "2030-02-01T03:30:00Z"},
+	}
+	for _, tt := range tests {
+		state := s.at(mustTime(t, tt.at))
+		var layers []string
+		for _, l := range state.layers {
+			layers = append(layers, l.user)
+		}
+		if !reflect.DeepEqual(layers, tt.layers) {
+			t.Errorf("at %s: layers have %q on call, want %q", tt.at, layers, tt.layers)
+		}
+		if !reflect.DeepEqual(state.users, tt.users) {
+			t.Errorf("at %s: users = %q, want %q", tt.at, state.users, tt.users)
+		}
+		if until := state.until.UTC().Format(time.RFC3339); until != tt.until {
+			t.Errorf("at %s: until = %s, want %s", tt.at, until, tt.until)
+		}
+	}
+}
+
+func TestOncallScheduleRestrictionWrapsAround(t *testing.T) {
+	// Sunday 20:00 for 12 hours runs into Monday of the next week.
+	s := testOncallSchedule(t, "UTC", []client.Layers{{
+		ShiftLength:       86400,
+		RotationStartTime: "2030-01-01T00:00:00Z",
+		Users:             []client.Users{{User: "alice"}},
+		RestrictionType:   restrictionWeekly,
+		Restrictions:      []client.Restrictions{{StartDayOfWeek: 7, StartTimeOfDay: "20:00:00", Duration: 12 * 3600}},
+	}}, nil)
+
+	state := s.at(mustTime(t, "2030-01-14T02:00:00Z"))
+	if state.layers[0].user != "alice" {
+		t.Errorf("Monday 02:00: %q on call, want alice", state.layers[0].user)
+	}
+	if state.layers[0].name != "Layer-1" {
+		t.Errorf("unnamed layer is called %q, want Layer-1", state.layers[0].name)
+	}
+	if until := state.until.Format(time.RFC3339); until != "2030-01-14T08:00:00Z" {
+		t.Errorf("until = %s, want 2030-01-14T08:00:00Z", until)
+	}
+}
+
+func TestOncallScheduleRestrictionKeepsLocalTimeAcrossDST(t *testing.T) {
+	// Daylight saving time starts on 2030-03-10 in New York, moving 09:00
+	// from 14:00 to 13:00 UTC.
+	s := testOncallSchedule(t, "America/New_York", []client.Layers{{
+		ShiftLength:       7 * 86400,
+		RotationStartTime: "2030-03-01T00:00:00Z",
+		Users:             []client.Users{{User: "alice"}},
+		RestrictionType:   restrictionDaily,
+		Restrictions:      []client.Restrictions{{StartDayOfWeek: 7, StartTimeOfDay: "09:00:00", Duration: 8 * 3600}},
+	}}, nil)
+
+	for at, want := range map[string]string{
+		"2030-03-09T13:30:00Z": "",
+		"2030-03-09T14:30:00Z": "alice",
+		"2030-03-10T13:30:00Z": "alice",
+		"2030-03-10T21:30:00Z": "",
+	} {
+		if got := s.at(mustTime(t, at)).layers[0].user; got != want {
+			t.Errorf("at %s: %q on call, want %q", at, got, want)
+		}
+	}
+}
+
+func TestOncallScheduleShifts(t *testing.T) {
+	s := testOncallSchedule(t, "UTC", []client.Layers{{
+		Name:              "Primary",
+		ShiftLength:       2 * 86400,
+		RotationStartTime: "2030-01-07T00:00:00Z",
+		Users:             []client.Users{{User: "alice"}, {User: "bob"}},
+		RestrictionType:   restrictionDaily,
+		Restrictions:      []client.Restrictions{{StartDayOfWeek: 7, StartTimeOfDay: "09:00:00", Duration: 8 * 3600}},
+	}}, []client.Overrides{{
+		Name:      "Swap",
+		StartTime: "2030-01-08T12:00:00Z",
+		EndTime:   "2030-01-08T14:00:00Z",
+		User:      "carol",
+	}})
+
+	type shift struct {
+		layer, user, start, end string
+		override                bool
+	}
+	want := []shift{
+		{"Primary", "alice", "2030-01-07T09:00:00Z", "2030-01-07T17:00:00Z", false},
+		{"Primary", "alice", "2030-01-08T09:00:00Z", "2030-01-08T12:00:00Z", false},
+		{"Swap", "carol", "2030-01-08T12:00:00Z", "2030-01-08T14:00:00Z", true},
+		{"Primary", "alice", "2030-01-08T14:00:00Z", "2030-01-08T17:00:00Z", false},
+		{"Primary", "bob", "2030-01-09T09:00:00Z", "2030-01-09T12:00:00Z", false},
+	}
+	var got []shift
+	for _, s := range s.shifts(mustTime(t, "2030-01-07T00:00:00Z"), mustTime(t, "2030-01-09T12:00:00Z")) {
+		got = append(got, shift{s.layer, s.user, s.start.Format(time.RFC3339), s.end.Format(time.RFC3339), s.override})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("shifts = %v, want %v", got, want)
+	}
+}
+
+func TestOncallScheduleShiftsBegan(t *testing.T) {
+	s := testOncallSchedule(t, "UTC", []client.Layers{{
+		Name:              "Primary",
+		ShiftLength:       86400,
+		RotationStartTime: "2030-01-07T00:00:00Z",
+		Users:             []client.Users{{User: "alice"}, {User: "alice"}, {User: "bob"}},
+	}, {
+		Name:              "Secondary",
+		ShiftLength:       86400,
+		RotationStartTime: "2030-01-07T00:00:00Z",
+		Users:             []client.Users{{User: "dave"}},
+		RestrictionType:   restrictionDaily,
+		Restrictions:      []client.Restrictions{{StartDayOfWeek: 7, StartTimeOfDay: "09:00:00", Duration: 8 * 3600}},
+	}}, []client.Overrides{{
+		Name:      "Swap",
+		StartTime: "2030-01-09T02:00:00Z",
+		EndTime:   "2030-01-09T04:00:00Z",
+		User:      "carol",
+	}})
+
+	cases := []struct {
+		from, layer, user, began string
+	}{
+		// alice is on call for two shifts in a row.
+		{"2030-01-08T12:00:00Z", "Primary", "alice", "2030-01-07T00:00:00Z"},
+		{"2030-01-08T12:00:00Z", "Secondary", "dave", "2030-01-08T09:00:00Z"},
+		{"2030-01-09T03:00:00Z", "Swap", "carol", "2030-01-09T02:00:00Z"},
+		// bob went on call when the override ended.
+		{"2030-01-09T05:00:00Z", "Primary", "bob", "2030-01-09T04:00:00Z"},
+		{"2030-01-10T12:00:00Z", "Primary", "alice", "2030-01-10T00:00:00Z"},
+		// dave is on call every day, but only inside the restriction.
+		{"2030-01-20T12:00:00Z", "Secondary", "dave", "2030-01-20T09:00:00Z"},
+	}
+	for _, c := range cases {
+		from := mustTime(t, c.from)
+		found := false
+		for _, shift := range s.shifts(from, from.Add(time.Hour)) {
+			if shift.layer != c.layer {
+				continue
+			}
+			found = true
+			if !shift.start.Equal(from) {
+				t.Errorf("from %s: %s shift starts at %s, want it cut to %s", c.from, c.layer, shift.start.Format(time.RFC3339), c.from)
+			}
+			if shift.user != c.user || !shift.began.Equal(mustTime(t, c.began)) {
+				t.Errorf("from %s: %s shift of %q began at %s, want %q at %s", c.from, c.layer, shift.user, shift.began.Format(time.RFC3339), c.user, c.began)
+			}
+		}
+		if !found {
+			t.Errorf("from %s: no %s shift", c.from, c.layer)
+		}
+	}
+}
+
+func TestOncallScheduleCoverage(t *testing.T) {
+	// Weekdays are covered by one layer and Friday afternoons by two, leaving
+	// the weekend uncovered but for an override on Saturday morning.
+	weekdays := client.Layers{
+		ShiftLength:       7 * 86400,
+		RotationStartTime: "2030-01-07T00:00:00Z",
+		Users:             []client.Users{{User: "alice"}},
+		RestrictionType:   restrictionWeekly,
+		Restrictions:      []client.Restrictions{{StartDayOfWeek: 1, StartTimeOfDay: "00:00:00", Duration: 5 * 86400}},
+	}
+	friday := client.Layers{
+		ShiftLength:       7 * 86400,
+		RotationStartTime: "2030-01-07T00:00:00Z",
+		Users:             []client.Users{{User: "bob"}},
+		RestrictionType:   restrictionWeekly,
+		Restrictions:      []client.Restrictions{{StartDayOfWeek: 5, StartTimeOfDay: "12:00:00", Duration: 12 * 3600}},
+	}
+	s := testOncallSchedule(t, "UTC", []client.Layers{weekdays, friday}, []client.Overrides{{
+		StartTime: "2030-01-12T06:00:00Z",
+		EndTime:   "2030-01-12T12:00:00Z",
+		User:      "carol",
+	}})
+
+	format := func(intervals []oncallInterval) []string {
+		var result []string
+		for _, i := range intervals {
+			result = append(result, fmt.Sprintf("%s %s %d", i.start.Format(time.RFC3339), i.end.Format(time.RFC3339), i.layers))
+		}
+		return result
+	}
+	if got, want := s.start(), mustTime(t, "2030-01-07T00:00:00Z"); !got.Equal(want) {
+		t.Errorf("start() = %s, want %s", got, want)
+	}
+	gaps, overlaps := s.coverage(mustTime(t, "2030-01-07T00:00:00Z"), mustTime(t, "2030-01-14T12:00:00Z"), 1)
+	wantGaps := []string{
+		"2030-01-12T00:00:00Z 2030-01-12T06:00:00Z 0",
+		"2030-01-12T12:00:00Z 2030-01-14T00:00:00Z 0",
+	}
+	if got := format(gaps); !reflect.DeepEqual(got, wantGaps) {
+		t.Errorf("gaps = %q, want %q", got, wantGaps)
+	}
+	wantOverlaps := []string{"2030-01-11T12:00:00Z 2030-01-12T00:00:00Z 2"}
+	if got := format(overlaps); !reflect.DeepEqual(got, wantOverlaps) {
+		t.Errorf("overlaps = %q, want %q", got, wantOverlaps)
+	}
+
+	if _, overlaps := s.coverage(mustTime(t, "2030-01-07T00:00:00Z"), mustTime(t, "2030-01-14T00:00:00Z"), 0); overlaps != nil {
+		t.Errorf("overlaps = %q without max_overlap, want none", format(overlaps))
+	}
+}
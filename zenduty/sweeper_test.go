@@ -0,0 +1,339 @@
+package zenduty
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Zenduty/zenduty-go-sdk/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Sweepers delete the objects that acceptance tests leave behind when they
+// fail midway, recognised by testAccNamePrefix. They run against the account
+// of ZENDUTY_API_KEY and ZENDUTY_BASE_URL:
+//
+//	go test ./zenduty -v -sweep=all
+//
+// The region argument required by -sweep is not used.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+// testAccSweepers are listed in the order they can run in: services before
+// the escalation policies they use, escalation policies before the
+// schedules they page, and teams last.
+var testAccSweepers = []*resource.Sweeper{
+	{
+		Name: "zenduty_services",
+		F:    sweepServices,
+	},
+	{
+		Name:         "zenduty_esp",
+		Dependencies: []string{"zenduty_services"},
+		F:            sweepEsps,
+	},
+	{
+		Name:         "zenduty_schedules",
+		Dependencies: []string{"zenduty_esp"},
+		F:            sweepSchedules,
+	},
+	{
+		Name:         "zenduty_teams",
+		Dependencies: []string{"zenduty_services", "zenduty_esp", "zenduty_schedules"},
+		F:            sweepTeams,
+	},
+	{
+		Name: "zenduty_globalrouter",
+		F:    sweepGlobalRouters,
+	},
+	{
+		Name: "zenduty_account_role",
+		F:    sweepAccountRoles,
+	},
+}
+
+func init() {
+	for _, s := range testAccSweepers {
+		resource.AddTestSweepers(s.Name, s)
+	}
+}
+
+// sweepClient returns a client for the account being swept.
+func sweepClient() (*client.Client, error) {
+	if os.Getenv("ZENDUTY_API_KEY") == "" {
+		return nil, errors.New("ZENDUTY_API_KEY must be set to run sweepers")
+	}
+	meta, err := testAccMeta()
+	if err != nil {
+		return nil, err
+	}
+	return meta.Client(context.Background())
+}
+
+// isSweepable reports whether an object named name was created by an
+// acceptance test.
+func isSweepable(name string) bool {
+	return strings.HasPrefix(name, testAccNamePrefix)
+}
+
+// sweepErrors collects the errors of a sweeper, so one object that cannot be
+// deleted does not keep the others from being swept.
+type sweepErrors []string
+
+func (e *sweepErrors) add(format string, a ...interface{}) {
+	err := fmt.Sprintf(format, a...)
+	log.Printf("[ERROR] %s", err)
+	*e = append(*e, err)
+}
+
+func (e sweepErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(e, "; "))
+}
+
+func sweepTeams(_ string) error {
+	apiclient, err := sweepClient()
+	if err != nil {
+		return err
+	}
+	teams, err := apiclient.Teams.GetTeams()
+	if err != nil {
+		return fmt.Errorf("listing teams: %w", err)
+	}
+	var errs sweepErrors
+	for _, team := range teams {
+		if !isSweepable(team.Name) {
+			continue
+		}
+		log.Printf("[INFO] Deleting team %s (%s)", team.Name, team.UniqueID)
+		if err := apiclient.Teams.DeleteTeam(team.UniqueID); err != nil && !isNotFoundError(err) {
+			errs.add("deleting team %s: %v", team.UniqueID, err)
+		}
+	}
+	return errs.err()
+}
+
+// sweepTeamObjects calls sweep with the ID of every team. Objects created by
+// tests can end up in any team, so team-scoped sweepers look in all of them.
+func sweepTeamObjects(sweep func(apiclient *client.Client, teamID string, errs *sweepErrors)) error {
+	apiclient, err := sweepClient()
+	if err != nil {
+		return err
+	}
+	teams, err := apiclient.Teams.GetTeams()
+	if err != nil {
+		return fmt.Errorf("listing teams: %w", err)
+	}
+	var errs sweepErrors
+	for _, team := range teams {
+		sweep(apiclient, team.UniqueID, &errs)
+	}
+	return errs.err()
+}
+
+func sweepServices(_ string) error {
+	return sweepTeamObjects(func(apiclient *client.Client, teamID string, errs *sweepErrors) {
+		services, err := apiclient.Services.GetServices(teamID)
+		if err != nil {
+			errs.add("listing services of team %s: %v", teamID, err)
+			return
+		}
+		for _, service := range services {
+			if !isSweepable(service.Name) {
+				continue
+			}
+			log.Printf("[INFO] Deleting service %s (%s)", service.Name, service.UniqueID)
+			if err := apiclient.Services.DeleteService(teamID, service.UniqueID); err != nil && !isNotFoundError(err) {
+				errs.add("deleting service %s: %v", service.UniqueID, err)
+			}
+		}
+	})
+}
+
+func sweepEsps(_ string) error {
+	return sweepTeamObjects(func(apiclient *client.Client, teamID string, errs *sweepErrors) {
+		esps, err := apiclient.Esp.GetEscalationPolicy(teamID)
+		if err != nil {
+			errs.add("listing escalation policies of team %s: %v", teamID, err)
+			return
+		}
+		for _, esp := range esps {
+			if !isSweepable(esp.Name) {
+				continue
+			}
+			log.Printf("[INFO] Deleting escalation policy %s (%s)", esp.Name, esp.UniqueID)
+			if err := apiclient.Esp.DeleteEscalationPolicy(teamID, esp.UniqueID); err != nil && !isNotFoundError(err) {
+				errs.add("deleting escalation policy %s: %v", esp.UniqueID, err)
+			}
+		}
+	})
+}
+
+func sweepSchedules(_ string) error {
+	return sweepTeamObjects(func(apiclient *client.Client, teamID string, errs *sweepErrors) {
+		schedules, err := apiclient.Schedules.GetSchedules(teamID)
+		if err != nil {
+			errs.add("listing schedules of team %s: %v", teamID, err)
+			return
+		}
+		for _, schedule := range schedules {
+			if !isSweepable(schedule.Name) {
+				continue
+			}
+			log.Printf("[INFO] Deleting schedule %s (%s)", schedule.Name, schedule.UniqueID)
+			if err := apiclient.Schedules.DeleteScheduleByID(teamID, schedule.UniqueID); err != nil && !isNotFoundError(err) {
+				errs.add("deleting schedule %s: %v", schedule.UniqueID, err)
+			}
+		}
+	})
+}
+
+func sweepGlobalRouters(_ string) error {
+	apiclient, err := sweepClient()
+	if err != nil {
+		return err
+	}
+	routers, err := apiclient.GlobalRouter.GetGlobalRouters()
+	if err != nil {
+		return fmt.Errorf("listing global routers: %w", err)
+	}
+	var errs sweepErrors
+	for _, router := range routers {
+		if !isSweepable(router.Name) {
+			continue
+		}
+		log.Printf("[INFO] Deleting global router %s (%s)", router.Name, router.UniqueID)
+		if err := apiclient.GlobalRouter.DeleteGlobalRouter(router.UniqueID); err != nil && !isNotFoundError(err) {
+			errs.add("deleting global router %s: %v", router.UniqueID, err)
+		}
+	}
+	return errs.err()
+}
+
+func sweepAccountRoles(_ string) error {
+	apiclient, err := sweepClient()
+	if err != nil {
+		return err
+	}
+	roles, err := apiclient.AccountRole.GetAccountRoles()
+	if err != nil {
+		return fmt.Errorf("listing account roles: %w", err)
+	}
+	var errs sweepErrors
+	for _, role := range roles {
+		if !isSweepable(role.Name) {
+			continue
+		}
+		log.Printf("[INFO] Deleting account role %s (%s)", role.Name, role.UniqueID)
+		if err := apiclient.AccountRole.DeleteAccountRole(role.UniqueID); err != nil && !isNotFoundError(err) {
+			errs.add("deleting account role %s: %v", role.UniqueID, err)
+		}
+	}
+	return errs.err()
+}
+
+// TestSweepers runs every sweeper against the fake API and checks that it
+// deletes the objects named like test objects and keeps the others.
+func TestSweepers(t *testing.T) {
+	api := newFakeAPI(t)
+	t.Setenv("ZENDUTY_API_KEY", api.token)
+	t.Setenv("ZENDUTY_BASE_URL", api.URL)
+	apiclient, err := sweepClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kept := "Production"
+	var teams []string
+	for _, name := range []string{testAccName(), kept} {
+		team, err := apiclient.Teams.CreateTeam(&client.CreateTeams{Name: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		teams = append(teams, team.UniqueID)
+	}
+	// Test objects in a team that is kept must be swept too.
+	for _, name := range []string{testAccName(), kept} {
+		if _, err := apiclient.Schedules.CreateSchedule(teams[1], &client.CreateSchedule{Name: name, TimeZone: "UTC", Team: teams[1]}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := apiclient.Esp.CreateEscalationPolicy(teams[1], &client.EscalationPolicy{Name: name, Team: teams[1]}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := apiclient.Services.CreateService(teams[1], &client.Services{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := apiclient.GlobalRouter.CreateGlobalRouter(&client.GlobalRouterPayload{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := apiclient.AccountRole.CreateAccountRole(&client.AccountRole{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, s := range testAccSweepers {
+		if err := s.F(""); err != nil {
+			t.Fatalf("%s: %v", s.Name, err)
+		}
+	}
+
+	var names []string
+	teamList, err := apiclient.Teams.GetTeams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, team := range teamList {
+		names = append(names, team.Name)
+	}
+	services, err := apiclient.Services.GetServices(teams[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, service := range services {
+		names = append(names, service.Name)
+	}
+	esps, err := apiclient.Esp.GetEscalationPolicy(teams[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, esp := range esps {
+		names = append(names, esp.Name)
+	}
+	schedules, err := apiclient.Schedules.GetSchedules(teams[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, schedule := range schedules {
+		names = append(names, schedule.Name)
+	}
+	routers, err := apiclient.GlobalRouter.GetGlobalRouters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, router := range routers {
+		names = append(names, router.Name)
+	}
+	roles, err := apiclient.AccountRole.GetAccountRoles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+
+	if len(names) != 6 {
+		t.Errorf("got %d objects after sweeping, want 6: %v", len(names), names)
+	}
+	for _, name := range names {
+		if name != kept {
+			t.Errorf("%s was not swept", name)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package zenduty
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Timestamps in the configuration of schedules, schedule overrides and
+// maintenance windows are either RFC 3339 timestamps, or local times in the
+// format of localTimestampFormat in the time zone set by another attribute of
+// the resource. The API takes and returns them as RFC 3339 timestamps in UTC;
+// they are read back as local times.
+//
+// timestampSchema returns the schema of such an attribute, which is checked
+// at plan time and compared by the instant it denotes, so that an RFC 3339
+// timestamp does not differ from the equivalent local time read back. Times
+// are read back to the minute, so timestamps with seconds are rejected.
+// validateLocalTimes checks local times against the time zone from
+// CustomizeDiff, and toAPITimestamp converts them for the API.
+
+// localTimestampFormat is the format of local times, YYYY-MM-DD HH:MM.
+const localTimestampFormat = "2006-01-02 15:04"
+
+// timestampSchema returns the schema of a timestamp attribute whose local
+// times are in the time zone of the attribute timeZoneKey.
+func timestampSchema(required bool, timeZoneKey string) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         required,
+		Optional:         !required,
+		ValidateDiagFunc: validateMinuteTimestamp(),
+		DiffSuppressFunc: suppressEquivalentTimestamps(timeZoneKey),
+	}
+}
+
+// parseTimestamp parses s as an RFC 3339 timestamp, or else as a local time
+// in loc.
+func parseTimestamp(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation(localTimestampFormat, s, loc)
+	if err != nil || len(s) != len(localTimestampFormat) {
+		return time.Time{}, fmt.Errorf("%q is neither an RFC 3339 timestamp nor a time in the format YYYY-MM-DD HH:MM", s)
+	}
+	return t, nil
+}
+
+// localTimeIssue describes what is wrong with s as a local time in loc
+// around a daylight saving time transition, or returns "" if nothing is.
+// Local times in the hour skipped when clocks go forward do not exist, and
+// local times in the hour repeated when clocks go back are ambiguous.
+func localTimeIssue(s string, loc *time.Location) string {
+	t, err := time.ParseInLocation(localTimestampFormat, s, loc)
+	if err != nil {
+		return ""
+	}
+	if t.In(loc).Format(localTimestampFormat) != s {
+		return fmt.Sprintf("%s does not exist in %s, as clocks go forward then", s, loc)
+	}
+	_, offset := t.Zone()
+	for _, d := range []time.Duration{30 * time.Minute, time.Hour, 2 * time.Hour} {
+		if later := t.Add(d); later.In(loc).Format(localTimestampFormat) == s {
+			if _, laterOffset := later.Zone(); laterOffset != offset {
+				return fmt.Sprintf("%s happens twice in %s, as clocks go back then", s, loc)
+			}
+		}
+	}
+	return ""
+}
+
+// checkLocalTime returns an error for the attribute at path when s is a
+// local time that does not exist, or happens twice, in loc.
+func checkLocalTime(s string, loc *time.Location, path cty.Path) error {
+	if issue := localTimeIssue(s, loc); issue != "" {
+		return path.NewErrorf("%s; use an RFC 3339 timestamp with an offset to pick the time", issue)
+	}
+	return nil
+}
+
+// toAPITimestamp converts the timestamp s of the attribute at path to an
+// RFC 3339 timestamp in UTC, taking local times in loc. Local times that do
+// not exist or happen twice in loc are rejected.
+func toAPITimestamp(s string, loc *time.Location, path cty.Path) (string, diag.Diagnostics) {
+	t, err := parseTimestamp(s, loc)
+	if err != nil {
+		return "", attributeDiag(path, err.Error())
+	}
+	if err := checkLocalTime(s, loc, path); err != nil {
+		return "", validationDiags(err)
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+// fromAPITimestamp converts an RFC 3339 timestamp returned by the API to a
+// local time in timeZone.
+func fromAPITimestamp(s, timeZone string) (string, error) {
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return "", err
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return "", err
+	}
+	return t.In(loc).Format(localTimestampFormat), nil
+}
+
+// validateTimestamp checks that a timestamp is in one of the accepted
+// formats. Whether a local time exists in its time zone is checked by
+// validateLocalTimes, which sees the time zone.
+func validateTimestamp() schema.SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		if _, err := parseTimestamp(v.(string), time.UTC); err != nil {
+			return attributeDiag(path, err.Error())
+		}
+		return nil
+	}
+}
+
+// validateMinuteTimestamp checks that a timestamp is in one of the accepted
+// formats and has no seconds, which the local time read back would drop,
+// showing a difference on every plan.
+func validateMinuteTimestamp() schema.SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		t, err := parseTimestamp(v.(string), time.UTC)
+		if err != nil {
+			return attributeDiag(path, err.Error())
+		}
+		if t.Second() != 0 || t.Nanosecond() != 0 {
+			return attributeDiagf(path, "%q has seconds, but times are kept to the minute", v.(string))
+		}
+		return nil
+	}
+}
+
+// suppressEquivalentTimestamps suppresses the diff of a timestamp between
+// values that denote the same instant, with local times in the time zone of
+// the attribute timeZoneKey. Local times do not denote the same instant once
+// the time zone changes, so nothing is suppressed then.
+func suppressEquivalentTimestamps(timeZoneKey string) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		if old == "" || new == "" || d.HasChange(timeZoneKey) {
+			return false
+		}
+		loc, err := time.LoadLocation(d.Get(timeZoneKey).(string))
+		if err != nil {
+			return false
+		}
+		oldTime, err := parseTimestamp(old, loc)
+		if err != nil {
+			return false
+		}
+		newTime, err := parseTimestamp(new, loc)
+		return err == nil && oldTime.Equal(newTime)
+	}
+}
+
+// validateLocalTimes checks at plan time that the timestamps at keys, such as
+// layers.0.rotation_start_time, are not local times that do not exist or
+// happen twice in the time zone of the attribute timeZoneKey. Nothing is
+// checked until the time zone is known.
+func validateLocalTimes(d resourceGetter, timeZoneKey string, keys ...string) error {
+	if !valueKnown(d, timeZoneKey) {
+		return nil
+	}
+	loc, err := time.LoadLocation(d.Get(timeZoneKey).(string))
+	if err != nil {
+		return nil
+	}
+	for _, key := range keys {
+		if !valueKnown(d, key) {
+			continue
+		}
+		s, _ := d.Get(key).(string)
+		if err := checkLocalTime(s, loc, keyPath(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyPath converts a key such as layers.0.rotation_start_time to its path.
+func keyPath(key string) cty.Path {
+	var path cty.Path
+	for _, step := range strings.Split(key, ".") {
+		if i, err := strconv.Atoi(step); err == nil {
+			path = path.IndexInt(i)
+		} else {
+			path = path.GetAttr(step)
+		}
+	}
+	return path
+}
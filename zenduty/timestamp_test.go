@@ -0,0 +1,174 @@
+package zenduty
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		s    string
+		want string
+	}{
+		{"2030-01-07 09:00", "2030-01-07T03:30:00Z"},
+		{"2030-01-07T03:30:00Z", "2030-01-07T03:30:00Z"},
+		{"2030-01-07T09:00:00+05:30", "2030-01-07T03:30:00Z"},
+		{"2030-01-07", ""},
+		{"2030-01-07 9:00", ""},
+		{"2030-02-30 09:00", ""},
+		{"07/01/2030 09:00", ""},
+		{"", ""},
+	}
+	for _, c := range cases {
+		got, err := parseTimestamp(c.s, kolkata)
+		if c.want == "" {
+			if err == nil {
+				t.Errorf("parseTimestamp(%q) = %s, want an error", c.s, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseTimestamp(%q): %s", c.s, err)
+		} else if got := got.UTC().Format(time.RFC3339); got != c.want {
+			t.Errorf("parseTimestamp(%q) = %s, want %s", c.s, got, c.want)
+		}
+	}
+}
+
+func TestLocalTimeIssue(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		s    string
+		want string
+	}{
+		{"2030-03-10 01:30", ""},
+		{"2030-03-10 02:30", "does not exist"},
+		{"2030-03-10 03:00", ""},
+		{"2030-11-03 00:30", ""},
+		{"2030-11-03 01:30", "happens twice"},
+		{"2030-11-03 02:00", ""},
+		{"2030-11-03T05:30:00Z", ""},
+	}
+	for _, c := range cases {
+		got := localTimeIssue(c.s, newYork)
+		if c.want == "" && got != "" || !strings.Contains(got, c.want) {
+			t.Errorf("localTimeIssue(%q) = %q, want %q", c.s, got, c.want)
+		}
+	}
+}
+
+func TestFromAPITimestamp(t *testing.T) {
+	got, err := fromAPITimestamp("2030-01-07T03:30:00Z", "Asia/Kolkata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "2030-01-07 09:00" {
+		t.Errorf("fromAPITimestamp = %q, want %q", got, "2030-01-07 09:00")
+	}
+	if _, err := fromAPITimestamp("2030-01-07 09:00", "Asia/Kolkata"); err == nil {
+		t.Error("fromAPITimestamp of a local time succeeded")
+	}
+	if _, err := fromAPITimestamp("2030-01-07T03:30:00Z", "Not/AZone"); err == nil {
+		t.Error("fromAPITimestamp with an invalid time zone succeeded")
+	}
+}
+
+func TestValidateMinuteTimestamp(t *testing.T) {
+	cases := []struct {
+		s       string
+		wantErr string
+	}{
+		{"2030-01-07 09:00", ""},
+		{"2030-01-07T03:30:00Z", ""},
+		{"2030-01-07T09:00:00.000+05:30", ""},
+		{"2030-01-07T03:30:15Z", "has seconds"},
+		{"2030-01-07T03:30:00.5Z", "has seconds"},
+		{"2030-01-07 9:00", "neither an RFC 3339 timestamp"},
+	}
+	for _, c := range cases {
+		diags := validateMinuteTimestamp()(c.s, cty.GetAttrPath("start_time"))
+		switch {
+		case c.wantErr == "" && diags.HasError():
+			t.Errorf("validateMinuteTimestamp(%q): %s", c.s, diags[0].Summary)
+		case c.wantErr != "" && (!diags.HasError() || !strings.Contains(diags[0].Summary, c.wantErr)):
+			t.Errorf("validateMinuteTimestamp(%q) = %v, want an error containing %q", c.s, diags, c.wantErr)
+		}
+	}
+}
+
+func TestToAPITimestamp(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		s       string
+		want    string
+		wantErr string
+	}{
+		{"2030-03-10 01:30", "2030-03-10T06:30:00Z", ""},
+		{"2030-03-10T07:30:00Z", "2030-03-10T07:30:00Z", ""},
+		{"2030-03-10 02:30", "", "does not exist"},
+		{"2030-11-03 01:30", "", "happens twice"},
+		{"2030-11-03T01:30:00-05:00", "2030-11-03T06:30:00Z", ""},
+	}
+	for _, c := range cases {
+		got, diags := toAPITimestamp(c.s, newYork, cty.GetAttrPath("start_time"))
+		if c.wantErr != "" {
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, c.wantErr) {
+				t.Errorf("toAPITimestamp(%q) = %q, %v, want an error containing %q", c.s, got, diags, c.wantErr)
+			}
+			continue
+		}
+		if diags.HasError() || got != c.want {
+			t.Errorf("toAPITimestamp(%q) = %q, %v, want %q", c.s, got, diags, c.want)
+		}
+	}
+}
+
+func TestValidateLocalTimes(t *testing.T) {
+	window := func(timeZone, start, repeatUntil string) map[string]interface{} {
+		return map[string]interface{}{
+			"timezone":     timeZone,
+			"start_time":   start,
+			"end_time":     "2030-11-04 09:00",
+			"repeat_until": repeatUntil,
+		}
+	}
+	cases := []struct {
+		name        string
+		raw         map[string]interface{}
+		wantPath    string
+		wantMessage string
+	}{
+		{"valid", window("America/New_York", "2030-11-03 09:00", ""), "", ""},
+		{"skipped hour", window("America/New_York", "2030-03-10 02:30", ""), "start_time", "does not exist in America/New_York"},
+		{"repeated hour", window("America/New_York", "2030-11-04 08:00", "2030-11-03 01:30"), "repeat_until", "happens twice in America/New_York"},
+		{"offset picks the time", window("America/New_York", "2030-11-03T01:30:00-05:00", ""), "", ""},
+		{"no transition in the time zone", window("Asia/Kolkata", "2030-03-10 02:30", ""), "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceMaintenanceWindow().Schema, c.raw)
+			testCheckPathError(t, validateLocalTimes(d, "timezone", "start_time", "end_time", "repeat_until"), c.wantPath, c.wantMessage)
+		})
+	}
+
+	layers := []interface{}{
+		map[string]interface{}{"rotation_start_time": "2030-01-07 09:00"},
+		map[string]interface{}{"rotation_start_time": "2030-03-10 02:00"},
+	}
+	d := schema.TestResourceDataRaw(t, resourceSchedules().Schema, map[string]interface{}{"time_zone": "America/New_York", "layers": layers})
+	testCheckPathError(t, validateLocalTimes(d, "time_zone", scheduleTimestampKeys(d)...), "layers[1].rotation_start_time", "does not exist")
+}
@@ -0,0 +1,136 @@
+package zenduty
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testGet sends a GET to url through the transport built from c.
+func testGet(t *testing.T, c *Config, url string) error {
+	t.Helper()
+	transport, err := c.newHTTPTransport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(url)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func TestNewHTTPTransport_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := ioutil.WriteFile(caFile, []byte(caPEM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{"system roots only", Config{}, "certificate"},
+		{"ca_cert_pem", Config{CACertPEM: caPEM}, ""},
+		{"ca_cert_file", Config{CACertFile: caFile}, ""},
+		{"insecure_skip_verify", Config{InsecureSkipVerify: true}, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := testGet(t, &c.config, server.URL)
+			switch {
+			case c.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case c.wantErr != "" && (err == nil || !strings.Contains(err.Error(), c.wantErr)):
+				t.Errorf("error = %v, want it to mention %q", err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewHTTPTransport_minimumTLSVersion(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS11}
+	server.StartTLS()
+	defer server.Close()
+
+	if err := testGet(t, &Config{InsecureSkipVerify: true}, server.URL); err == nil {
+		t.Error("a server limited to TLS 1.1 was accepted, want TLS 1.2 at least")
+	}
+}
+
+func TestNewHTTPTransport_invalidSettings(t *testing.T) {
+	cases := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{"proxy_url", Config{ProxyURL: "://proxy"}, "invalid proxy_url"},
+		{"ca_cert_pem", Config{CACertPEM: "not a certificate"}, "no PEM encoded certificates found in ca_cert_pem"},
+		{"missing ca_cert_file", Config{CACertFile: filepath.Join(t.TempDir(), "missing.pem")}, "unable to read ca_cert_file"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := c.config.newHTTPTransport()
+			if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+				t.Errorf("error = %v, want it to mention %q", err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewHTTPTransport_proxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.Method+" "+r.URL.String())
+	}))
+	defer proxy.Close()
+
+	if err := testGet(t, &Config{ProxyURL: proxy.URL}, "http://zenduty.invalid/api/account/teams/"); err != nil {
+		t.Fatal(err)
+	}
+	if len(proxied) != 1 || proxied[0] != "GET http://zenduty.invalid/api/account/teams/" {
+		t.Errorf("the proxy got %q, want the request for zenduty.invalid", proxied)
+	}
+}
+
+func TestTimeoutTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: &timeoutTransport{base: http.DefaultTransport, timeout: 50 * time.Millisecond}}
+
+	resp, err := client.Get(server.URL + "/fast")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil || string(body) != "ok" {
+		t.Errorf("body = %q, %v, want the body readable until it is closed", body, err)
+	}
+
+	if _, err := client.Get(server.URL + "/slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}